@@ -17,12 +17,39 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// FastCleanupAnnotation marks a CronJob whose history should be cleaned with
+// the cleaner's fast retention count instead of its regular one.
+const FastCleanupAnnotation = "cleaner.lifecycle.github.io/fast-cleanup"
+
+// LastRunAnnotation holds a JSON summary of the cleaner's last pass when
+// spec.writeSummaryAnnotation is set.
+const LastRunAnnotation = "cleaner.lifecycle.github.io/last-run"
+
+// LastCleanupAnnotation holds a JSON summary of the last pass that deleted
+// Jobs, written to the target CronJob when spec.annotateTargetCronJob is set.
+const LastCleanupAnnotation = "cleaner.lifecycle.github.io/last-cleanup"
+
+// DeletedByAnnotation names the cleaner, as namespace/name, that deleted a
+// Job. It is written just before the deletion when spec.markDeletedBy is set,
+// so that the deletion can be traced in API server audit logs.
+const DeletedByAnnotation = "cleaner.lifecycle.github.io/deleted-by"
+
+// DeletionReasonAnnotation holds why a Job was deleted, next to
+// DeletedByAnnotation.
+const DeletionReasonAnnotation = "cleaner.lifecycle.github.io/deletion-reason"
+
+// DeletionRequestedAtAnnotation holds when the Job was marked for deletion,
+// next to DeletedByAnnotation.
+const DeletionRequestedAtAnnotation = "cleaner.lifecycle.github.io/deletion-requested-at"
+
 // CronExecutionCleanerSpec defines the desired state of CronExecutionCleaner
 type CronExecutionCleanerSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
@@ -32,9 +59,51 @@ type CronExecutionCleanerSpec struct {
 	// +kubebuilder:validation:MinLength=1
 	Namespace string `json:"namespace"`
 
-	// Name of the CronJob whose executions should be cleaned
+	// Name of the CronJob whose executions should be cleaned. Required
+	// unless cronJobNames is set.
 	// +kubebuilder:validation:MinLength=1
-	CronJobName string `json:"cronJobName"`
+	// +optional
+	CronJobName string `json:"cronJobName,omitempty"`
+
+	// Names of CronJobs whose executions should be cleaned, in addition to
+	// cronJobName. Retention counts apply to each CronJob separately. The
+	// first name, or cronJobName when set, is the one read for fastRetain
+	// and annotated by annotateTargetCronJob.
+	// +optional
+	CronJobNames []string `json:"cronJobNames,omitempty"`
+
+	// Select Jobs by the labels of the target CronJob's job template instead
+	// of by owner references
+	// +optional
+	UseJobTemplateLabels bool `json:"useJobTemplateLabels,omitempty"`
+
+	// Only clean Jobs matching this label selector. With a CronJob name set,
+	// a Job must also be owned by one of the named CronJobs; without one,
+	// every matching Job in the namespace is cleaned regardless of its owner.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Kinds of owner named by cronJobName whose Jobs are cleaned. Defaults to
+	// CronJob.
+	// +optional
+	OwnerKinds []string `json:"ownerKinds,omitempty"`
+
+	// Jobs whose names match this regular expression are left alone entirely
+	// +optional
+	ExcludeNameRegex string `json:"excludeNameRegex,omitempty"`
+
+	// CEL expression over the Job, bound to object, that protects the Job
+	// from deletion when it evaluates to true. A Job the expression fails
+	// on, e.g. by selecting a missing field, is protected too; test for
+	// optional fields with has() or in.
+	// +optional
+	ProtectExpression string `json:"protectExpression,omitempty"`
+
+	// List Jobs directly from the API server instead of the controller's
+	// cache, so deletions are decided on up-to-date data at the cost of a
+	// quorum read
+	// +optional
+	StrongConsistency bool `json:"strongConsistency,omitempty"`
 
 	// Retention policy for completed Jobs
 	Retain RetentionPolicy `json:"retain"`
@@ -44,8 +113,176 @@ type CronExecutionCleanerSpec struct {
 
 	// Interval at which cleanup logic runs
 	RunInterval metav1.Duration `json:"runInterval"`
+
+	// Interval at which cleanup runs while the target CronJob cannot be
+	// found. Defaults to four times runInterval.
+	// +optional
+	MissingTargetRequeue *metav1.Duration `json:"missingTargetRequeue,omitempty"`
+
+	// Run again as soon as the next Job leaves its grace period or becomes
+	// stuck, instead of waiting for the full runInterval
+	// +optional
+	RequeueOnEligibility bool `json:"requeueOnEligibility,omitempty"`
+
+	// Maximum number of Jobs this cleaner deletes in a single run. The cap
+	// is per cleaner, not per namespace: cleaners sharing a namespace each
+	// get their own. Use the --max-deletions-per-namespace manager flag to
+	// bound deletions across all cleaners in a namespace. Zero means no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxDeletionsPerRun int `json:"maxDeletionsPerRun,omitempty"`
+
+	// Number of consecutive runs that hit maxDeletionsPerRun
+	// without the owned Job count going down before the Starved condition
+	// is set. Defaults to 3.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	StarvationThreshold int `json:"starvationThreshold,omitempty"`
+
+	// Maximum number of condition types kept in status. The conditions that
+	// transitioned least recently are dropped first; Ready is always kept.
+	// Defaults to 8.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxConditions int `json:"maxConditions,omitempty"`
+
+	// Number of Jobs after which the cleaner stops deleting and sets the
+	// CeilingReached condition for a human to review. Deletions count from
+	// the last spec change, so bumping the spec lifts the stop. Zero means no
+	// ceiling.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	LifetimeDeletionCeiling int `json:"lifetimeDeletionCeiling,omitempty"`
+
+	// Smooths deletions to an average rate with a token bucket, on top of
+	// the other caps. Tokens accrue between runs up to the burst and are
+	// kept in status across runs. Unset deletes as fast as the other caps
+	// allow.
+	// +optional
+	DeletionRateLimit *DeletionRateLimit `json:"deletionRateLimit,omitempty"`
+
+	// Only match Jobs whose CronJob owner reference is the controller owner
+	// +optional
+	RequireControllerOwner bool `json:"requireControllerOwner,omitempty"`
+
+	// Time to wait after the cleaner is first reconciled before any Jobs are
+	// deleted. During warm-up the cleanup plan is only logged.
+	// +optional
+	WarmupPeriod *metav1.Duration `json:"warmupPeriod,omitempty"`
+
+	// Skip Jobs whose Pods still reference a bound PersistentVolumeClaim
+	// +optional
+	RespectPVCReferences bool `json:"respectPVCReferences,omitempty"`
+
+	// Defer deleting completed Jobs while a volume of one of their Pods is
+	// still attached to the Pod's node, so no VolumeAttachment is left behind
+	// +optional
+	RespectVolumeDetach bool `json:"respectVolumeDetach,omitempty"`
+
+	// How long failures must persist before the Ready condition turns False
+	// +optional
+	ReadyDebounce *metav1.Duration `json:"readyDebounce,omitempty"`
+
+	// Number of consecutive failed runs before the Ready condition turns False
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ReadyFailureThreshold int `json:"readyFailureThreshold,omitempty"`
+
+	// Propagation policy used to delete Jobs. With Foreground a Job is only
+	// counted as deleted once it is gone. Defaults to Background.
+	// +kubebuilder:validation:Enum=Background;Foreground
+	// +optional
+	DeletePropagation metav1.DeletionPropagation `json:"deletePropagation,omitempty"`
+
+	// Delete the Jobs of each category in order of creation, oldest first,
+	// so that the load on the API server is predictable
+	// +optional
+	DeterministicDeletionOrder bool `json:"deterministicDeletionOrder,omitempty"`
+
+	// Delete Services labeled job-name=<job> together with their Job
+	// +optional
+	CleanupAssociatedServices bool `json:"cleanupAssociatedServices,omitempty"`
+
+	// Remove ttlSecondsAfterFinished from owned Jobs so that the cleaner is
+	// the only thing deleting them
+	// +optional
+	AdoptTTLJobs bool `json:"adoptTTLJobs,omitempty"`
+
+	// Write a JSON summary of each pass to the last-run annotation
+	// +optional
+	WriteSummaryAnnotation bool `json:"writeSummaryAnnotation,omitempty"`
+
+	// Write a JSON summary of each pass that deleted Jobs to the last-cleanup
+	// annotation of the target CronJob
+	// +optional
+	AnnotateTargetCronJob bool `json:"annotateTargetCronJob,omitempty"`
+
+	// Annotate each Job with this cleaner and the reason just before deleting
+	// it, for post-mortem correlation. Best effort.
+	// +optional
+	MarkDeletedBy bool `json:"markDeletedBy,omitempty"`
+
+	// Only log and audit the Jobs that would be deleted or quarantined,
+	// without touching them
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Never delete, update or patch Jobs or any other object the cleaner
+	// acts on, whatever the rest of the spec asks for. The cleaner only
+	// evaluates its target and reports through its own status.
+	// +optional
+	ReadOnly bool `json:"readOnly,omitempty"`
+
+	// URL POSTed a JSON summary of the deleted Jobs once per run, only when
+	// the run deleted Jobs and every Job selected for deletion was deleted.
+	// The URL's host must be allowed by the operator. A failed call is
+	// logged and does not fail the run.
+	// +optional
+	PostCleanupWebhook string `json:"postCleanupWebhook,omitempty"`
+
+	// Like dryRun, but only for retention of succeeded and failed Jobs
+	// +optional
+	DryRunRetention bool `json:"dryRunRetention,omitempty"`
+
+	// Like dryRun, but only for stuck and abandoned Jobs
+	// +optional
+	DryRunStuck bool `json:"dryRunStuck,omitempty"`
+
+	// Suspend pauses cleanup without removing the resource
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// Daily window during which the cleaner runs. Outside it the cleaner
+	// waits, and it is requeued exactly when the window opens and closes.
+	// Runs at any time if unset.
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
 }
 
+// CleanerPhase is a high-level summary of the cleaner's state
+// +kubebuilder:validation:Enum=Idle;Cleaning;Suspended;Waiting;Error;Invalid
+type CleanerPhase string
+
+const (
+	// PhaseIdle means the last run found nothing to delete
+	PhaseIdle CleanerPhase = "Idle"
+
+	// PhaseCleaning means the last run deleted one or more Jobs
+	PhaseCleaning CleanerPhase = "Cleaning"
+
+	// PhaseSuspended means cleanup is paused via spec.suspend
+	PhaseSuspended CleanerPhase = "Suspended"
+
+	// PhaseWaiting means cleanup waits for the maintenance window to open
+	PhaseWaiting CleanerPhase = "Waiting"
+
+	// PhaseError means the last run failed
+	PhaseError CleanerPhase = "Error"
+
+	// PhaseInvalid means the spec failed validation
+	PhaseInvalid CleanerPhase = "Invalid"
+)
+
 // CronExecutionCleanerStatus defines the observed state of CronExecutionCleaner
 type CronExecutionCleanerStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
@@ -54,12 +291,146 @@ type CronExecutionCleanerStatus struct {
 	// Last time the cleanup ran
 	LastRunTime *metav1.Time `json:"lastRunTime,omitempty"`
 
+	// Last time Jobs were evaluated for cleanup. Only refreshed together with
+	// another status change.
+	// +optional
+	LastEvaluatedTime *metav1.Time `json:"lastEvaluatedTime,omitempty"`
+
+	// Earliest time a Job kept by the last run becomes eligible for
+	// deletion. Only set with requeueOnEligibility.
+	// +optional
+	NextEligibleTime *metav1.Time `json:"nextEligibleTime,omitempty"`
+
+	// When a run cut short by API server throttling is retried, ahead of
+	// the run interval
+	// +optional
+	RetryTime *metav1.Time `json:"retryTime,omitempty"`
+
+	// Generation of the spec that was last evaluated
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// UID of the object this status was recorded for. A different UID means
+	// the cleaner was recreated, e.g. restored from a backup, and its
+	// counters are reset.
+	// +optional
+	ObservedUID types.UID `json:"observedUID,omitempty"`
+
 	// Total number of Jobs deleted
 	JobsDeleted int `json:"jobsDeleted,omitempty"`
 
 	// Total number of Pods deleted
 	PodsDeleted int `json:"podsDeleted,omitempty"`
 
+	// Jobs deleted with foreground propagation that were still terminating
+	// at the end of the last run. They are counted as deleted once gone.
+	// +optional
+	PendingForegroundDeletions []string `json:"pendingForegroundDeletions,omitempty"`
+
+	// Total resource requests of the Jobs in pendingForegroundDeletions,
+	// added to reclaimedResources as they are gone
+	// +optional
+	PendingForegroundResources corev1.ResourceList `json:"pendingForegroundResources,omitempty"`
+
+	// Jobs deleted by hour of day in UTC, index 0 being midnight to 1am.
+	// Empty until the first deletion, 24 entries afterwards.
+	// +optional
+	// +kubebuilder:validation:MaxItems=24
+	HourlyDeletions []int `json:"hourlyDeletions,omitempty"`
+
+	// Number of Jobs deleted since the spec last changed, counted against
+	// the lifetime deletion ceiling
+	// +optional
+	DeletionsSinceSpecChange int `json:"deletionsSinceSpecChange,omitempty"`
+
+	// Total number of Services deleted together with their Jobs
+	// +optional
+	ServicesDeleted int `json:"servicesDeleted,omitempty"`
+
+	// Total resource requests of the pod templates of deleted Jobs
+	// +optional
+	ReclaimedResources corev1.ResourceList `json:"reclaimedResources,omitempty"`
+
+	// Time the warm-up period started
+	// +optional
+	WarmupStartedAt *metav1.Time `json:"warmupStartedAt,omitempty"`
+
+	// Whether the warm-up period has elapsed
+	// +optional
+	WarmupComplete bool `json:"warmupComplete,omitempty"`
+
+	// Number of stuck Jobs found in the last run
+	// +optional
+	StuckJobs int `json:"stuckJobs,omitempty"`
+
+	// Names of the stuck Jobs found in the last run
+	// +optional
+	StuckJobNames []string `json:"stuckJobNames,omitempty"`
+
+	// Number of consecutive failed runs
+	// +optional
+	ConsecutiveFailures int `json:"consecutiveFailures,omitempty"`
+
+	// Time of the first failure in the current streak of failed runs
+	// +optional
+	FailingSince *metav1.Time `json:"failingSince,omitempty"`
+
+	// Last time the target CronJob was scheduled, as reported by the CronJob
+	// +optional
+	TargetLastScheduleTime *metav1.Time `json:"targetLastScheduleTime,omitempty"`
+
+	// Share of failed Jobs among completed Jobs in the last run
+	// +optional
+	FailureRatio string `json:"failureRatio,omitempty"`
+
+	// Number of Jobs owned by the target CronJob in the last run, before
+	// any were deleted
+	// +optional
+	OwnedJobCount int `json:"ownedJobCount,omitempty"`
+
+	// Change in ownedJobCount since the run before, 0 on the first run. A
+	// delta that stays positive means cleanup is not keeping up.
+	// +optional
+	OwnedJobCountDelta int `json:"ownedJobCountDelta,omitempty"`
+
+	// Number of Jobs owned by the target CronJob that were already being
+	// deleted by someone else in the last run. They are left alone and not
+	// counted in ownedJobCount.
+	// +optional
+	AlreadyTerminating int `json:"alreadyTerminating,omitempty"`
+
+	// Number of Jobs the last run would have deleted but left in place
+	// because of dryRun, dryRunRetention or dryRunStuck
+	// +optional
+	JobsWouldDelete int `json:"jobsWouldDelete,omitempty"`
+
+	// Consecutive runs that hit the per-run deletion cap while the
+	// owned Job count did not go down
+	// +optional
+	StarvedRuns int `json:"starvedRuns,omitempty"`
+
+	// When the Jobs held back by maxDeletionsPerRun in the last
+	// run are expected to be deleted, at one capped run per runInterval
+	// +optional
+	EstimatedDrainTime *metav1.Time `json:"estimatedDrainTime,omitempty"`
+
+	// Deletions left in the token bucket of spec.deletionRateLimit
+	// +optional
+	DeletionTokens int `json:"deletionTokens,omitempty"`
+
+	// When deletionTokens was last refilled
+	// +optional
+	DeletionTokensRefilledAt *metav1.Time `json:"deletionTokensRefilledAt,omitempty"`
+
+	// High-level summary of the cleaner's state
+	// +optional
+	Phase CleanerPhase `json:"phase,omitempty"`
+
+	// Plain English summary of the last run, e.g.
+	// "Deleted 3 succeeded, 1 stuck; skipped 2 at 2026-01-01T00:00Z"
+	// +optional
+	Message string `json:"message,omitempty"`
+
 	// Current state of the cleaner
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
@@ -85,14 +456,151 @@ type CronExecutionCleanerList struct {
 	Items           []CronExecutionCleaner `json:"items"`
 }
 
+// RetainAll as a retention count keeps every Job of that kind.
+const RetainAll = -1
+
 type RetentionPolicy struct {
-	// Number of successful Jobs to retain
-	// +kubebuilder:validation:Minimum=0
+	// Number of successful Jobs to retain, or -1 to retain all of them
+	// +kubebuilder:validation:Minimum=-1
 	SuccessfulJobs int `json:"successfulJobs"`
 
-	// Number of failed Jobs to retain
-	// +kubebuilder:validation:Minimum=0
+	// Number of failed Jobs to retain, or -1 to retain all of them
+	// +kubebuilder:validation:Minimum=-1
 	FailedJobs int `json:"failedJobs"`
+
+	// Number of completed manually triggered Jobs to retain, or -1 to retain
+	// all of them. Manual Jobs, such as those created with kubectl create job
+	// --from=cronjob/..., are then kept apart from scheduled ones and do not
+	// count against successfulJobs and failedJobs. Unset counts them as
+	// scheduled Jobs.
+	// +kubebuilder:validation:Minimum=-1
+	// +optional
+	ManualJobs *int `json:"manualJobs,omitempty"`
+
+	// Failure ratio (0 to 1) among completed Jobs above which failed
+	// retention is elevated, e.g. "0.5"
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +optional
+	ElevateThresholdRatio string `json:"elevateThresholdRatio,omitempty"`
+
+	// Factor applied to failedJobs while the failure ratio is above the
+	// threshold. Defaults to 2.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ElevateFactor int `json:"elevateFactor,omitempty"`
+
+	// Number of successful Jobs kept per calendar day (UTC) of completion,
+	// on top of successfulJobs. Defaults to 1 when daysToKeep is set.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PerDay int `json:"perDay,omitempty"`
+
+	// Number of most recent calendar days, including today, for which
+	// perDay successful Jobs are kept
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	DaysToKeep int `json:"daysToKeep,omitempty"`
+
+	// How long a succeeded Job is left alone after completing before it
+	// counts against successfulJobs
+	// +optional
+	SuccessfulGrace *metav1.Duration `json:"successfulGrace,omitempty"`
+
+	// How long a failed Job is left alone after failing before it counts
+	// against failedJobs
+	// +optional
+	FailedGrace *metav1.Duration `json:"failedGrace,omitempty"`
+
+	// How long after completing a succeeded Job is deleted, even when
+	// successfulJobs would keep it
+	// +optional
+	SuccessfulTTL *metav1.Duration `json:"successfulTTL,omitempty"`
+
+	// How long after failing a failed Job is deleted, even when failedJobs
+	// would keep it
+	// +optional
+	FailedTTL *metav1.Duration `json:"failedTTL,omitempty"`
+
+	// Number of successful and failed Jobs to retain while the target CronJob
+	// carries the fast-cleanup annotation
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FastRetain *int `json:"fastRetain,omitempty"`
+
+	// Number of successful Jobs to retain on given weekdays (UTC) instead of
+	// successfulJobs, keyed by English weekday name, e.g. Saturday. Names are
+	// matched case-insensitively, and each weekday may have only one override.
+	// +optional
+	WeekdayOverrides map[string]int `json:"weekdayOverrides,omitempty"`
+
+	// Lower successfulJobs and failedJobs to the target CronJob's own
+	// successfulJobsHistoryLimit and failedJobsHistoryLimit where those are
+	// smaller, so that the cleaner does not fight the CronJob's own cleanup
+	// +optional
+	AlignWithCronJobHistoryLimit bool `json:"alignWithCronJobHistoryLimit,omitempty"`
+
+	// Pod annotation that must be set to "true" on every Pod of a completed
+	// Job before the Job is deleted, e.g. by a log-shipping sidecar. Jobs
+	// whose Pods lack it are deferred to a later run.
+	// +optional
+	RequireLogsShippedAnnotation string `json:"requireLogsShippedAnnotation,omitempty"`
+
+	// Only delete excess failed Jobs whose JobFailed condition message
+	// contains this substring. Other failed Jobs are kept.
+	// +optional
+	FailedMessageContains string `json:"failedMessageContains,omitempty"`
+
+	// Container exit codes whose failed Jobs are kept for investigation,
+	// e.g. 137 for OOM kills. A failed Job is kept when any container of its
+	// Pods terminated with one of them.
+	// +optional
+	KeepFailedExitCodes []int32 `json:"keepFailedExitCodes,omitempty"`
+
+	// Delete the excess failed Jobs with the highest resource requests first,
+	// so that a per-run deletion cap reclaims the most expensive runs
+	// +optional
+	PrioritizeHighResource bool `json:"prioritizeHighResource,omitempty"`
+
+	// Label whose value groups Jobs into batches. When set, successfulJobs
+	// and failedJobs count batches instead of Jobs, and every Job of an older
+	// batch is deleted together. Jobs without the label form a batch of their
+	// own.
+	// +optional
+	AtomicByLabel string `json:"atomicByLabel,omitempty"`
+
+	// Label whose value names the deploy generation a Job belongs to. When
+	// set, completed Jobs of generations older than the current one and the
+	// keepPreviousGenerations before it are deleted regardless of
+	// successfulJobs and failedJobs. Generations are ordered by their newest
+	// Job, and Jobs without the label are left to the other rules.
+	// +optional
+	CurrentGenerationLabel string `json:"currentGenerationLabel,omitempty"`
+
+	// Number of generations before the current one whose Jobs are kept
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	KeepPreviousGenerations int `json:"keepPreviousGenerations,omitempty"`
+}
+
+type DeletionRateLimit struct {
+	// Number of Jobs that may be deleted per minute on average
+	// +kubebuilder:validation:Minimum=1
+	PerMinute int `json:"perMinute"`
+
+	// Number of Jobs that may be deleted at once after a quiet period.
+	// Defaults to perMinute.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Burst int `json:"burst,omitempty"`
+}
+
+type MaintenanceWindow struct {
+	// Time of day the window opens, as HH:MM in UTC
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// How long the window stays open, at most 24h
+	Duration metav1.Duration `json:"duration"`
 }
 
 type CleanupStuckPolicy struct {
@@ -101,8 +609,71 @@ type CleanupStuckPolicy struct {
 
 	// Duration after which a running Job is considered stuck
 	StuckAfter metav1.Duration `json:"stuckAfter"`
+
+	// Only flag a Job as stuck once its Pods have finished init containers
+	// and satisfied their readiness gates
+	// +optional
+	RequirePodProgressStall bool `json:"requirePodProgressStall,omitempty"`
+
+	// Measure stuckAfter from the last condition transition or probe of the
+	// Job's Pods instead of from the Job's start time
+	// +optional
+	UsePodConditionAge bool `json:"usePodConditionAge,omitempty"`
+
+	// Also treat active Jobs as stuck when one of their Pods has been
+	// unschedulable for longer than stuckAfter, whatever the Job's start time
+	// +optional
+	IncludeUnschedulable bool `json:"includeUnschedulable,omitempty"`
+
+	// Skip stuck Jobs whose Pods are still within their startup probe budget
+	// +optional
+	ExcludeWithinStartupProbe bool `json:"excludeWithinStartupProbe,omitempty"`
+
+	// Skip stuck Jobs that started less than the target CronJob's
+	// startingDeadlineSeconds ago
+	// +optional
+	RespectStartingDeadline bool `json:"respectStartingDeadline,omitempty"`
+
+	// Only report stuck Jobs through status and events instead of deleting them
+	// +optional
+	ReportOnly bool `json:"reportOnly,omitempty"`
+
+	// What to do with stuck Jobs. Defaults to delete.
+	// +optional
+	Action StuckAction `json:"action,omitempty"`
+
+	// Label key set to "true" on quarantined Jobs. Defaults to
+	// cleaner.lifecycle.github.io/quarantined.
+	// +optional
+	QuarantineLabel string `json:"quarantineLabel,omitempty"`
+
+	// Active Jobs that started longer ago than this are treated as
+	// abandoned: stuck right away, regardless of stuckAfter or of how their
+	// Pods are progressing
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+
+	// What to do with abandoned Jobs. Defaults to action.
+	// +optional
+	MaxAgeAction StuckAction `json:"maxAgeAction,omitempty"`
 }
 
+// StuckAction is what the cleaner does with a stuck Job
+// +kubebuilder:validation:Enum=delete;quarantine
+type StuckAction string
+
+const (
+	// StuckActionDelete deletes stuck Jobs
+	StuckActionDelete StuckAction = "delete"
+
+	// StuckActionQuarantine labels stuck Jobs and leaves them in place
+	StuckActionQuarantine StuckAction = "quarantine"
+)
+
+// DefaultQuarantineLabel is the label set on quarantined Jobs unless
+// spec.cleanupStuck.quarantineLabel says otherwise.
+const DefaultQuarantineLabel = "cleaner.lifecycle.github.io/quarantined"
+
 func init() {
 	SchemeBuilder.Register(&CronExecutionCleaner{}, &CronExecutionCleanerList{})
 }
@@ -21,6 +21,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -29,6 +30,11 @@ import (
 func (in *CleanupStuckPolicy) DeepCopyInto(out *CleanupStuckPolicy) {
 	*out = *in
 	out.StuckAfter = in.StuckAfter
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupStuckPolicy.
@@ -46,7 +52,7 @@ func (in *CronExecutionCleaner) DeepCopyInto(out *CronExecutionCleaner) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -103,9 +109,49 @@ func (in *CronExecutionCleanerList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CronExecutionCleanerSpec) DeepCopyInto(out *CronExecutionCleanerSpec) {
 	*out = *in
-	out.Retain = in.Retain
-	out.CleanupStuck = in.CleanupStuck
+	if in.CronJobNames != nil {
+		in, out := &in.CronJobNames, &out.CronJobNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OwnerKinds != nil {
+		in, out := &in.OwnerKinds, &out.OwnerKinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Retain.DeepCopyInto(&out.Retain)
+	in.CleanupStuck.DeepCopyInto(&out.CleanupStuck)
 	out.RunInterval = in.RunInterval
+	if in.MissingTargetRequeue != nil {
+		in, out := &in.MissingTargetRequeue, &out.MissingTargetRequeue
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DeletionRateLimit != nil {
+		in, out := &in.DeletionRateLimit, &out.DeletionRateLimit
+		*out = new(DeletionRateLimit)
+		**out = **in
+	}
+	if in.WarmupPeriod != nil {
+		in, out := &in.WarmupPeriod, &out.WarmupPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ReadyDebounce != nil {
+		in, out := &in.ReadyDebounce, &out.ReadyDebounce
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronExecutionCleanerSpec.
@@ -125,6 +171,67 @@ func (in *CronExecutionCleanerStatus) DeepCopyInto(out *CronExecutionCleanerStat
 		in, out := &in.LastRunTime, &out.LastRunTime
 		*out = (*in).DeepCopy()
 	}
+	if in.LastEvaluatedTime != nil {
+		in, out := &in.LastEvaluatedTime, &out.LastEvaluatedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextEligibleTime != nil {
+		in, out := &in.NextEligibleTime, &out.NextEligibleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RetryTime != nil {
+		in, out := &in.RetryTime, &out.RetryTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PendingForegroundDeletions != nil {
+		in, out := &in.PendingForegroundDeletions, &out.PendingForegroundDeletions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingForegroundResources != nil {
+		in, out := &in.PendingForegroundResources, &out.PendingForegroundResources
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.HourlyDeletions != nil {
+		in, out := &in.HourlyDeletions, &out.HourlyDeletions
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReclaimedResources != nil {
+		in, out := &in.ReclaimedResources, &out.ReclaimedResources
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.WarmupStartedAt != nil {
+		in, out := &in.WarmupStartedAt, &out.WarmupStartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.StuckJobNames != nil {
+		in, out := &in.StuckJobNames, &out.StuckJobNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailingSince != nil {
+		in, out := &in.FailingSince, &out.FailingSince
+		*out = (*in).DeepCopy()
+	}
+	if in.TargetLastScheduleTime != nil {
+		in, out := &in.TargetLastScheduleTime, &out.TargetLastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EstimatedDrainTime != nil {
+		in, out := &in.EstimatedDrainTime, &out.EstimatedDrainTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DeletionTokensRefilledAt != nil {
+		in, out := &in.DeletionTokensRefilledAt, &out.DeletionTokensRefilledAt
+		*out = (*in).DeepCopy()
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -144,9 +251,82 @@ func (in *CronExecutionCleanerStatus) DeepCopy() *CronExecutionCleanerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeletionRateLimit) DeepCopyInto(out *DeletionRateLimit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeletionRateLimit.
+func (in *DeletionRateLimit) DeepCopy() *DeletionRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(DeletionRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RetentionPolicy) DeepCopyInto(out *RetentionPolicy) {
 	*out = *in
+	if in.ManualJobs != nil {
+		in, out := &in.ManualJobs, &out.ManualJobs
+		*out = new(int)
+		**out = **in
+	}
+	if in.SuccessfulGrace != nil {
+		in, out := &in.SuccessfulGrace, &out.SuccessfulGrace
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.FailedGrace != nil {
+		in, out := &in.FailedGrace, &out.FailedGrace
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.SuccessfulTTL != nil {
+		in, out := &in.SuccessfulTTL, &out.SuccessfulTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.FailedTTL != nil {
+		in, out := &in.FailedTTL, &out.FailedTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.FastRetain != nil {
+		in, out := &in.FastRetain, &out.FastRetain
+		*out = new(int)
+		**out = **in
+	}
+	if in.WeekdayOverrides != nil {
+		in, out := &in.WeekdayOverrides, &out.WeekdayOverrides
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.KeepFailedExitCodes != nil {
+		in, out := &in.KeepFailedExitCodes, &out.KeepFailedExitCodes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetentionPolicy.
@@ -17,18 +17,30 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
+	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -52,11 +64,33 @@ func init() {
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "scaffold":
+			os.Exit(runScaffold(os.Args[2:]))
+		case "simulate":
+			os.Exit(runSimulate(os.Args[2:]))
+		case "preflight":
+			os.Exit(runPreflight(os.Args[2:]))
+		}
+	}
+
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var maxConcurrentReconciles int
+	var largeCleanerJobs int
+	var maxDeletionsPerNamespace int
+	var minRunInterval time.Duration
+	var enableObjectMetrics bool
+	var aggregateMetrics bool
+	var enableTracing bool
+	var reconcileOrder string
+	var auditLog string
+	var pauseConfigMap string
+	var postCleanupWebhookHosts string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
@@ -66,6 +100,35 @@ func main() {
 		"If set the metrics endpoint is served securely")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 1,
+		"Number of CronExecutionCleaners reconciled in parallel")
+	flag.IntVar(&largeCleanerJobs, "large-cleaner-jobs", 0,
+		"If set, CronExecutionCleaners that owned at least this many Jobs in their last run are reconciled "+
+			"by a separate controller with its own queue and workers, so they cannot delay smaller ones")
+	flag.IntVar(&maxDeletionsPerNamespace, "max-deletions-per-namespace", 0,
+		"Maximum number of Job deletions in flight in any one namespace, across all CronExecutionCleaners. "+
+			"Unlimited if 0.")
+	flag.DurationVar(&minRunInterval, "min-run-interval", 0,
+		"Shortest run interval any CronExecutionCleaner may use. Shorter intervals are raised to it. Disabled if 0.")
+	flag.BoolVar(&enableObjectMetrics, "enable-object-metrics", false,
+		"If set, per-object series for each CronExecutionCleaner are served on /metrics/objects")
+	flag.BoolVar(&aggregateMetrics, "aggregate-metrics", false,
+		"If set, metrics are emitted without namespace and name labels to bound their cardinality. "+
+			"Cannot be combined with --enable-object-metrics.")
+	flag.StringVar(&reconcileOrder, "reconcile-order", "fifo",
+		"Order in which CronExecutionCleaners are first reconciled, e.g. on startup: fifo, or priority "+
+			"to reconcile cleaners whose CronJobs have the highest share of failed Jobs first")
+	flag.BoolVar(&enableTracing, "enable-tracing", false,
+		"If set, each reconcile phase is traced as an OpenTelemetry span and exported over OTLP/HTTP, "+
+			"configured by the standard OTEL_EXPORTER_OTLP_* environment variables")
+	flag.StringVar(&auditLog, "audit-log", "",
+		"File to append a JSON audit record of every deleted Job to, or - for stdout. Disabled if empty.")
+	flag.StringVar(&pauseConfigMap, "pause-configmap", "",
+		"ConfigMap, as namespace/name, whose paused key pauses every CronExecutionCleaner when set to \"true\". "+
+			"Disabled if empty.")
+	flag.StringVar(&postCleanupWebhookHosts, "post-cleanup-webhook-hosts", "",
+		"Comma-separated hosts, as host or host:port, that CronExecutionCleaner post-cleanup webhooks may call. "+
+			"Webhooks to any other host are not called.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -94,12 +157,81 @@ func main() {
 		TLSOpts: tlsOpts,
 	})
 
+	if reconcileOrder != "fifo" && reconcileOrder != "priority" {
+		setupLog.Error(nil, "--reconcile-order must be fifo or priority", "value", reconcileOrder)
+		os.Exit(1)
+	}
+
+	var pauseKey types.NamespacedName
+	var cacheOpts cache.Options
+	if pauseConfigMap != "" {
+		namespace, name, ok := strings.Cut(pauseConfigMap, "/")
+		if !ok || namespace == "" || name == "" {
+			setupLog.Error(nil, "--pause-configmap must be namespace/name", "value", pauseConfigMap)
+			os.Exit(1)
+		}
+		pauseKey = types.NamespacedName{Namespace: namespace, Name: name}
+		// Only the pause ConfigMap is cached, not every ConfigMap in the cluster
+		cacheOpts.ByObject = map[client.Object]cache.ByObject{
+			&corev1.ConfigMap{}: {
+				Namespaces: map[string]cache.Config{namespace: {}},
+				Field:      fields.OneTermEqualSelector("metadata.name", name),
+			},
+		}
+	}
+
+	if aggregateMetrics {
+		if enableObjectMetrics {
+			setupLog.Error(nil, "--aggregate-metrics cannot be combined with --enable-object-metrics")
+			os.Exit(1)
+		}
+		controller.AggregateMetrics()
+	}
+
+	var objectMetrics *controller.ObjectMetrics
+	metricsExtraHandlers := map[string]http.Handler{}
+	if enableObjectMetrics {
+		objectMetrics = controller.NewObjectMetrics()
+		metricsExtraHandlers["/metrics/objects"] = objectMetrics.Handler()
+	}
+
+	var tracerProvider trace.TracerProvider
+	shutdownTracing := func(context.Context) error { return nil }
+	if enableTracing {
+		// The exporter is configured by the standard OTEL_EXPORTER_OTLP_*
+		// environment variables
+		exporter, err := otlptracehttp.New(context.Background())
+		if err != nil {
+			setupLog.Error(err, "unable to create trace exporter")
+			os.Exit(1)
+		}
+		provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+		tracerProvider = provider
+		shutdownTracing = provider.Shutdown
+	}
+
+	var auditSink controller.AuditSink
+	switch auditLog {
+	case "":
+	case "-":
+		auditSink = controller.NewJSONAuditSink(os.Stdout)
+	default:
+		fileSink, err := controller.NewFileAuditSink(auditLog)
+		if err != nil {
+			setupLog.Error(err, "unable to open audit log", "path", auditLog)
+			os.Exit(1)
+		}
+		auditSink = fileSink
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
+		Cache:  cacheOpts,
 		Metrics: metricsserver.Options{
 			BindAddress:   metricsAddr,
 			SecureServing: secureMetrics,
 			TLSOpts:       tlsOpts,
+			ExtraHandlers: metricsExtraHandlers,
 		},
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
@@ -123,8 +255,18 @@ func main() {
 	}
 
 	if err = (&controller.CronExecutionCleanerReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:                  mgr.GetClient(),
+		Scheme:                  mgr.GetScheme(),
+		MaxConcurrentReconciles: maxConcurrentReconciles,
+		PrioritizeFailing:       reconcileOrder == "priority",
+		LargeCleanerJobs:        largeCleanerJobs,
+		PostCleanupWebhookHosts: splitList(postCleanupWebhookHosts),
+		ObjectMetrics:           objectMetrics,
+		TracerProvider:          tracerProvider,
+		AuditSink:               auditSink,
+		PauseConfigMap:          pauseKey,
+		MinRunInterval:          minRunInterval,
+		NamespaceFence:          controller.NewNamespaceFence(maxDeletionsPerNamespace),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "CronExecutionCleaner")
 		os.Exit(1)
@@ -145,4 +287,19 @@ func main() {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+	// Export the spans still buffered
+	if err := shutdownTracing(context.Background()); err != nil {
+		setupLog.Error(err, "unable to flush traces")
+	}
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
@@ -0,0 +1,74 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/bhatpriyanka8/cron-execution-cleaner/internal/preflight"
+)
+
+// runPreflight checks that the current credentials can reach the API server
+// and hold the permissions cleanup needs in a namespace, and returns the
+// process exit code. Nothing in the cluster is changed.
+func runPreflight(args []string) int {
+	fs := flag.NewFlagSet("preflight", flag.ContinueOnError)
+	namespace := fs.String("namespace", "", "Namespace the cleaners will clean")
+	timeout := fs.Duration("timeout", 30*time.Second, "How long to wait for the API server")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *namespace == "" {
+		fmt.Fprintln(os.Stderr, "--namespace is required")
+		return 2
+	}
+
+	config, err := ctrl.GetConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	c, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	missing, err := preflight.Check(ctx, c, *namespace)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot reach the API server: %v\n", err)
+		return 1
+	}
+	if len(missing) > 0 {
+		fmt.Printf("Missing permissions in namespace %s:\n", *namespace)
+		for _, permission := range missing {
+			fmt.Printf("  %s\n", permission)
+		}
+		return 1
+	}
+	fmt.Printf("All %d required permissions granted in namespace %s\n", len(preflight.RequiredPermissions), *namespace)
+	return 0
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bhatpriyanka8/cron-execution-cleaner/internal/scaffold"
+)
+
+// runScaffold prints a sample CronExecutionCleaner manifest and returns the
+// process exit code.
+func runScaffold(args []string) int {
+	fs := flag.NewFlagSet("scaffold", flag.ContinueOnError)
+	scenario := fs.String("scenario", "basic",
+		"Sample to generate, one of: "+strings.Join(scaffold.Scenarios(), ", "))
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	manifest, err := scaffold.Render(*scenario)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if _, err := os.Stdout.Write(manifest); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"sigs.k8s.io/yaml"
+
+	lifecyclev1alpha1 "github.com/bhatpriyanka8/cron-execution-cleaner/api/v1alpha1"
+	"github.com/bhatpriyanka8/cron-execution-cleaner/internal/controller"
+)
+
+// runSimulate prints what a cleaner would delete from a list of Jobs and
+// returns the process exit code. Nothing is read from or written to a cluster.
+func runSimulate(args []string) int {
+	fs := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	cleanerPath := fs.String("cleaner", "", "Path to a CronExecutionCleaner manifest")
+	jobsPath := fs.String("jobs", "", "Path to a list of Jobs, e.g. the output of kubectl get jobs -o yaml")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *cleanerPath == "" || *jobsPath == "" {
+		fmt.Fprintln(os.Stderr, "both --cleaner and --jobs are required")
+		return 2
+	}
+
+	var cleaner lifecyclev1alpha1.CronExecutionCleaner
+	if err := readManifest(*cleanerPath, &cleaner); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	var jobs batchv1.JobList
+	if err := readManifest(*jobsPath, &jobs); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	cleaner.Spec = controller.EffectiveSpec(&cleaner)
+	toDelete, toRetain, byReason := controller.Impact(cleaner.Spec, jobs.Items)
+
+	fmt.Printf("Jobs to delete: %d\n", toDelete)
+	reasons := make([]string, 0, len(byReason))
+	for reason := range byReason {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+	for _, reason := range reasons {
+		fmt.Printf("  %s: %d\n", reason, byReason[reason])
+	}
+	fmt.Printf("Jobs to retain: %d\n", toRetain)
+	return 0
+}
+
+func readManifest(path string, obj interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := yaml.Unmarshal(data, obj); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"context"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// apiVerbs are the verbs recorded per reconcile. Every verb is observed on
+// each reconcile, including those that were not used.
+var apiVerbs = []string{"get", "list", "create", "update", "patch", "delete"}
+
+// apiCallCounter counts the API server calls made during a single reconcile.
+type apiCallCounter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+type apiCallCounterKey struct{}
+
+// withAPICallCounter returns a context whose API server calls are counted by
+// the returned counter.
+func withAPICallCounter(ctx context.Context) (context.Context, *apiCallCounter) {
+	counter := &apiCallCounter{counts: map[string]int{}}
+	return context.WithValue(ctx, apiCallCounterKey{}, counter), counter
+}
+
+func countAPICall(ctx context.Context, verb string) {
+	counter, ok := ctx.Value(apiCallCounterKey{}).(*apiCallCounter)
+	if !ok {
+		return
+	}
+	counter.mu.Lock()
+	defer counter.mu.Unlock()
+	counter.counts[verb]++
+}
+
+// observe records the counted calls in the per-reconcile histogram.
+func (c *apiCallCounter) observe() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, verb := range apiVerbs {
+		apiCallsPerReconcile.WithLabelValues(verb).Observe(float64(c.counts[verb]))
+	}
+}
+
+// countingClient counts the calls made through it against the counter found
+// in the request context.
+type countingClient struct {
+	client.Client
+}
+
+func newCountingClient(c client.Client) client.Client {
+	if _, ok := c.(countingClient); ok {
+		return c
+	}
+	return countingClient{Client: c}
+}
+
+func (c countingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	countAPICall(ctx, "get")
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func (c countingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	countAPICall(ctx, "list")
+	return c.Client.List(ctx, list, opts...)
+}
+
+func (c countingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	countAPICall(ctx, "create")
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c countingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	countAPICall(ctx, "update")
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c countingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	countAPICall(ctx, "patch")
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c countingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	countAPICall(ctx, "delete")
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c countingClient) Status() client.SubResourceWriter {
+	return countingStatusWriter{SubResourceWriter: c.Client.Status()}
+}
+
+// countingReader counts the reads made through it, like countingClient.
+type countingReader struct {
+	client.Reader
+}
+
+func newCountingReader(r client.Reader) client.Reader {
+	if _, ok := r.(countingReader); ok {
+		return r
+	}
+	return countingReader{Reader: r}
+}
+
+func (r countingReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	countAPICall(ctx, "get")
+	return r.Reader.Get(ctx, key, obj, opts...)
+}
+
+func (r countingReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	countAPICall(ctx, "list")
+	return r.Reader.List(ctx, list, opts...)
+}
+
+// countingStatusWriter counts status writes under the update and patch verbs.
+type countingStatusWriter struct {
+	client.SubResourceWriter
+}
+
+func (w countingStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	countAPICall(ctx, "update")
+	return w.SubResourceWriter.Update(ctx, obj, opts...)
+}
+
+func (w countingStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	countAPICall(ctx, "patch")
+	return w.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+}
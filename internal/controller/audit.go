@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// AuditRecord describes a single Job deletion made by a cleaner.
+type AuditRecord struct {
+	// Time of the deletion
+	Time time.Time `json:"time"`
+
+	// Cleaner that deleted the Job, as namespace/name
+	Actor string `json:"actor"`
+
+	// Deleted Job, as namespace/name
+	Object string `json:"object"`
+
+	// Why the Job was deleted: abandoned, stuck, succeeded or failed
+	Reason string `json:"reason"`
+
+	// Whether the deletion was only simulated
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// AuditSink receives one record per Job deletion, separate from the
+// controller logs.
+type AuditSink interface {
+	Record(ctx context.Context, record AuditRecord) error
+}
+
+// JSONAuditSink writes each record as a line of JSON.
+type JSONAuditSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewJSONAuditSink returns a sink writing JSON lines to w.
+func NewJSONAuditSink(w io.Writer) *JSONAuditSink {
+	return &JSONAuditSink{w: w}
+}
+
+// NewFileAuditSink returns a sink appending JSON lines to the file at path,
+// creating it if needed. Existing records are never rewritten.
+func NewFileAuditSink(path string) (*JSONAuditSink, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return NewJSONAuditSink(f), nil
+}
+
+// Record implements AuditSink.
+func (s *JSONAuditSink) Record(_ context.Context, record AuditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(line, '\n'))
+	return err
+}
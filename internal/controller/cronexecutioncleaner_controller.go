@@ -18,15 +18,30 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	lifecyclev1alpha1 "github.com/bhatpriyanka8/cron-execution-cleaner/api/v1alpha1"
@@ -37,16 +52,91 @@ type CronExecutionCleanerReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+
+	// Clock is used for all time-based decisions. Defaults to the real clock.
+	Clock clock.PassiveClock
+
+	// MaxConcurrentReconciles is the number of cleaners reconciled in
+	// parallel, so that one slow namespace cannot hold up the others.
+	// Defaults to 1.
+	MaxConcurrentReconciles int
+
+	// ObjectMetrics, when set, receives the latest status of every cleaner
+	// for per-object metrics.
+	ObjectMetrics *ObjectMetrics
+
+	// APIReader reads directly from the API server, bypassing the cache, for
+	// cleaners that ask for strong consistency. Defaults to the manager's.
+	APIReader client.Reader
+
+	// PrioritizeFailing queues cleaners whose targets are failing ahead of
+	// the others when they are first seen, e.g. on startup, instead of in
+	// arrival order.
+	PrioritizeFailing bool
+
+	// LargeCleanerJobs, when positive, moves cleaners that owned at least
+	// this many Jobs in their last run to a controller of their own, so that
+	// they do not hold up the work queue and workers of the smaller ones.
+	LargeCleanerJobs int
+
+	// PauseConfigMap names a ConfigMap whose paused key, when "true", pauses
+	// every cleaner. Disabled if the name is empty.
+	PauseConfigMap types.NamespacedName
+
+	// TracerProvider, when set, provides the tracer that receives an
+	// OpenTelemetry span for each phase of a reconcile.
+	TracerProvider trace.TracerProvider
+
+	// AuditSink, when set, receives a record of every deleted Job.
+	AuditSink AuditSink
+
+	// MinRunInterval is the shortest run interval any cleaner may use.
+	// Shorter intervals are raised to it. Disabled if zero.
+	MinRunInterval time.Duration
+
+	// Results, when set, receives a ReconcileResult after every reconcile.
+	// Results are dropped rather than waited on when the channel is full.
+	Results chan<- ReconcileResult
+
+	// NamespaceFence, when set, bounds concurrent Job deletions per
+	// namespace across all cleaners.
+	NamespaceFence *NamespaceFence
+
+	// HTTPClient is used for post-cleanup webhooks. Defaults to
+	// http.DefaultClient; every call is bounded by a 10 second timeout.
+	HTTPClient *http.Client
+
+	// PostCleanupWebhookHosts are the hosts, as host or host:port, that
+	// post-cleanup webhooks may call. Webhooks to other hosts are not
+	// called, so none are unless the operator allows some.
+	PostCleanupWebhookHosts []string
+
+	// postCleanupCalls tracks post-cleanup webhook calls in flight.
+	postCleanupCalls sync.WaitGroup
+
+	// sleep replaces the pause between throttled deletions in tests.
+	sleep func(ctx context.Context, d time.Duration) error
+
+	// statusSubresourceMissing is set once the CRD turns out to have been
+	// installed without the status subresource. Status is then written with
+	// plain updates for the rest of the process lifetime.
+	statusSubresourceMissing atomic.Bool
 }
 
 // RBAC permissions
-//+kubebuilder:rbac:groups=lifecycle.github.io,resources=cronexecutioncleaners,verbs=get;list;watch
+//+kubebuilder:rbac:groups=lifecycle.github.io,resources=cronexecutioncleaners,verbs=get;list;watch;update;patch
 //+kubebuilder:rbac:groups=lifecycle.github.io,resources=cronexecutioncleaners/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=lifecycle.github.io,resources=cronexecutioncleaners/finalizers,verbs=update
 
-// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;patch;delete
+// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;patch
 
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=volumeattachments,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -57,17 +147,96 @@ type CronExecutionCleanerReconciler struct {
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.17.0/pkg/reconcile
-func (r *CronExecutionCleanerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *CronExecutionCleanerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	_ = log.FromContext(ctx)
 
+	ctx, apiCalls := withAPICallCounter(ctx)
+	defer apiCalls.observe()
+
+	// Sent last, once a panic has been turned into an error
+	outcome := &ReconcileResult{Cleaner: req.NamespacedName}
+	defer func() {
+		outcome.Err = err
+		r.sendResult(ctx, *outcome)
+	}()
+
+	// A panic while handling one cleaner must not take down the manager
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result, err = r.recoverPanic(ctx, req, recovered)
+		}
+	}()
+
+	return r.reconcile(ctx, req, outcome)
+}
+
+// reconcile runs a single cleanup pass for the cleaner, recording what it
+// did in outcome.
+func (r *CronExecutionCleanerReconciler) reconcile(
+	ctx context.Context,
+	req ctrl.Request,
+	outcome *ReconcileResult,
+) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 	log.Info("Reconciling CronExecutionCleaner", "name", req.NamespacedName)
 
+	// Errors are returned with an empty Result, since controller-runtime
+	// ignores RequeueAfter alongside an error and backs off on its own. Every
+	// other return requeues, except once the cleaner is gone.
 	var cleaner lifecyclev1alpha1.CronExecutionCleaner
 	if err := r.Get(ctx, req.NamespacedName, &cleaner); err != nil {
 		log.Error(err, "unable to fetch CronExecutionCleaner")
+		if apierrors.IsNotFound(err) {
+			r.ObjectMetrics.forget(req.NamespacedName)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	// Status as read, to skip writes that would not change anything
+	observed := cleaner.Status.DeepCopy()
+
+	if resetForRecreatedCleaner(&cleaner) {
+		log.Info("CronExecutionCleaner was recreated, resetting its counters", "uid", cleaner.UID)
+	}
+
+	// Act on the effective spec from here on. Only the status subresource is
+	// written back, so resolved defaults never leak into the stored spec.
+	cleaner.Spec = EffectiveSpec(&cleaner)
+
+	requested := cleaner.Spec.RunInterval
+	if clampRunInterval(&cleaner, r.MinRunInterval) {
+		log.Info("Run interval is below the controller minimum, using the minimum",
+			"runInterval", requested.Duration.String(), "minRunInterval", r.MinRunInterval.String())
+		setCondition(
+			&cleaner,
+			"RunIntervalClamped",
+			metav1.ConditionTrue,
+			"BelowMinimum",
+			fmt.Sprintf("runInterval %s is below the controller minimum, running every %s",
+				requested.Duration, r.MinRunInterval),
+		)
+	} else {
+		meta.RemoveStatusCondition(&cleaner.Status.Conditions, "RunIntervalClamped")
+	}
+
+	paused, err := r.globallyPaused(ctx)
+	if err != nil {
+		log.Error(err, "Failed to read the pause ConfigMap", "configMap", r.PauseConfigMap)
+		return ctrl.Result{}, err
+	}
+	if paused {
+		log.Info("All cleaners are paused, skipping cleanup", "name", req.NamespacedName, "configMap", r.PauseConfigMap)
+		setCondition(
+			&cleaner,
+			"GloballyPaused",
+			metav1.ConditionTrue,
+			"PausedByConfigMap",
+			fmt.Sprintf("Cleanup is paused by ConfigMap %s", r.PauseConfigMap),
+		)
+
+		r.updateStatus(ctx, &cleaner, observed)
+		return ctrl.Result{RequeueAfter: requeueInterval(&cleaner)}, nil
+	}
+	meta.RemoveStatusCondition(&cleaner.Status.Conditions, "GloballyPaused")
 
 	if err := validateSpec(ctx, &cleaner); err != nil {
 		log.Error(err, "Invalid CronExecutionCleaner spec, skipping reconciliation", "name", req.NamespacedName)
@@ -86,9 +255,25 @@ func (r *CronExecutionCleanerReconciler) Reconcile(ctx context.Context, req ctrl
 			"InvalidSpec",
 			err.Error(),
 		)
+		cleaner.Status.Phase = lifecyclev1alpha1.PhaseInvalid
+
+		r.updateStatus(ctx, &cleaner, observed)
+		return ctrl.Result{RequeueAfter: requeueInterval(&cleaner)}, nil
+	}
+
+	if cleaner.Spec.Suspend {
+		log.Info("CronExecutionCleaner is suspended, skipping cleanup", "name", req.NamespacedName)
+		setCondition(
+			&cleaner,
+			"Ready",
+			metav1.ConditionFalse,
+			"Suspended",
+			"Cleanup is suspended",
+		)
+		cleaner.Status.Phase = lifecyclev1alpha1.PhaseSuspended
 
-		_ = r.Status().Update(ctx, &cleaner)
-		return ctrl.Result{}, nil
+		r.updateStatus(ctx, &cleaner, observed)
+		return ctrl.Result{RequeueAfter: requeueInterval(&cleaner)}, nil
 	}
 
 	log.Info(
@@ -98,83 +283,360 @@ func (r *CronExecutionCleanerReconciler) Reconcile(ctx context.Context, req ctrl
 		"Retain", cleaner.Spec.Retain,
 		"CleanupStuck", cleaner.Spec.CleanupStuck,
 		"RunInterval", cleaner.Spec.RunInterval,
+		"Suspend", cleaner.Spec.Suspend,
+		"MaxDeletionsPerRun", cleaner.Spec.MaxDeletionsPerRun,
 	)
 
-	var jobList batchv1.JobList
+	now := r.now()
+	// Runs inside a maintenance window requeue no later than it closes
+	var windowCloses *time.Time
+	if window := cleaner.Spec.MaintenanceWindow; window != nil {
+		open, boundary := maintenanceWindowAt(window, now)
+		if !open {
+			log.Info("Outside the maintenance window, waiting for it to open", "opensAt", boundary)
+			setCondition(
+				&cleaner,
+				"Ready",
+				metav1.ConditionFalse,
+				"OutsideMaintenanceWindow",
+				fmt.Sprintf("Cleanup waits for the maintenance window to open at %s", boundary.Format(time.RFC3339)),
+			)
+			cleaner.Status.Phase = lifecyclev1alpha1.PhaseWaiting
+
+			r.updateStatus(ctx, &cleaner, observed)
+			return ctrl.Result{RequeueAfter: boundary.Sub(now)}, nil
+		}
+		windowCloses = &boundary
+	}
+	if delay := evaluationDelay(&cleaner, now); delay > 0 {
+		if windowCloses != nil {
+			delay = min(delay, windowCloses.Sub(now))
+		}
+		log.Info("Spec unchanged and nothing due yet, skipping evaluation", "requeueAfter", delay.String())
+		return ctrl.Result{RequeueAfter: delay}, nil
+	}
 
-	err := r.List(ctx, &jobList, client.InNamespace(cleaner.Spec.Namespace))
+	cronJobs, err := r.getTargetCronJobs(ctx, &cleaner)
 	if err != nil {
-		log.Error(err, "unable to list Jobs for CronExecutionCleaner")
-		return ctrl.Result{}, err
+		log.Error(err, "unable to get target CronJob")
+	}
+	// Cleaners selecting Jobs by label alone have no target to miss
+	targetMissing := err == nil && len(cronJobs) == 0 && len(cleaner.Spec.CronJobNames) > 0
+	// Settings that read or write the target CronJob act on the first one
+	cronJob := cronJobs[cleaner.Spec.CronJobName]
+	if cronJob != nil {
+		cleaner.Status.TargetLastScheduleTime = cronJob.Status.LastScheduleTime
+	}
+
+	var jobList batchv1.JobList
+
+	listOpts := []client.ListOption{client.InNamespace(cleaner.Spec.Namespace)}
+	selectable := true
+	if cleaner.Spec.UseJobTemplateLabels {
+		templateLabels := jobTemplateLabels(cronJob)
+		if len(templateLabels) == 0 {
+			// Without labels every Job in the namespace would match
+			log.Info("Target CronJob has no job template labels, nothing to select")
+			r.Recorder.Event(
+				&cleaner,
+				corev1.EventTypeWarning,
+				"JobTemplateLabelsUnavailable",
+				"Target CronJob not found or its job template has no labels",
+			)
+			selectable = false
+		}
+		listOpts = append(listOpts, client.MatchingLabels(templateLabels))
+	}
+	if cleaner.Spec.Selector != nil {
+		// The selector has been validated along with the rest of the spec
+		selector, _ := metav1.LabelSelectorAsSelector(cleaner.Spec.Selector)
+		listOpts = append(listOpts, client.MatchingLabelsSelector{Selector: selector})
+	}
+
+	if selectable {
+		// The client reads from the informer cache unless a live read was
+		// asked for
+		var reader client.Reader = r.Client
+		if cleaner.Spec.StrongConsistency && r.APIReader != nil {
+			reader = r.APIReader
+		}
+		listCtx, span := r.startSpan(ctx, spanList)
+		err := reader.List(listCtx, &jobList, listOpts...)
+		span.End()
+		if err != nil {
+			log.Error(err, "unable to list Jobs for CronExecutionCleaner")
+			recordFailure(&cleaner, now, "ListFailed", err.Error())
+			if isConnectionError(err) && cleaner.Status.ConsecutiveFailures >= apiUnavailableThreshold {
+				setCondition(
+					&cleaner,
+					"Degraded",
+					metav1.ConditionTrue,
+					"APIUnavailable",
+					fmt.Sprintf("Could not reach the API server in the last %d runs: %v", cleaner.Status.ConsecutiveFailures, err),
+				)
+			}
+			cleaner.Status.Phase = lifecyclev1alpha1.PhaseError
+
+			r.updateStatus(ctx, &cleaner, observed)
+			return ctrl.Result{}, err
+		}
+
+		// Jobs deleted in the foreground are only counted once they are gone
+		jobList.Items = settleForegroundDeletions(&cleaner, jobList.Items, now)
+	}
+
+	if applyWeekdayRetention(&cleaner.Spec, now) {
+		log.Info("Applying weekday retention override", "weekday", now.UTC().Weekday(), "retain", cleaner.Spec.Retain.SuccessfulJobs)
+	}
+	// Fast retention only ever applies when the target CronJob can be read;
+	// otherwise the regular retention counts are kept.
+	if applyFastRetention(&cleaner.Spec, cronJob) {
+		log.Info("Target CronJob requests fast cleanup", "fastRetain", *cleaner.Spec.Retain.FastRetain)
+	}
+
+	// Jobs this cleaner already decided to delete are not planned again
+	leftovers := findLeftoverJobs(&cleaner, jobList.Items, now)
+	if len(leftovers) > 0 {
+		log.Info("Found Jobs marked for deletion that are still present, deleting them again", "jobs", jobNames(leftovers))
+		jobList.Items = withoutJobs(jobList.Items, leftovers)
 	}
 
-	ownedJobs := filterJobsByOwner(jobList.Items, cleaner.Spec.CronJobName)
+	_, span := r.startSpan(ctx, spanClassify)
+	plan := planDeletions(&cleaner, cronJobs, jobList.Items, now)
+	span.End()
+	for reason, count := range plan.Skipped {
+		if count > 0 {
+			jobsSkipped.WithLabelValues(reason).Add(float64(count))
+		}
+	}
+	if len(plan.Foreign) > 0 {
+		jobsSkipped.WithLabelValues("foreign-owner").Add(float64(len(plan.Foreign)))
+		log.Info(
+			"Skipping Jobs whose owner reference does not match the target CronJob",
+			"jobs", jobNames(plan.Foreign),
+		)
+		r.Recorder.Eventf(
+			&cleaner,
+			corev1.EventTypeWarning,
+			eventReason(&cleaner.Spec, "ForeignOwnerReference"),
+			"Skipping %d Jobs owned by a %s of the same name in another namespace: %s",
+			len(plan.Foreign),
+			strings.Join(cleaner.Spec.CronJobNames, " or "),
+			strings.Join(jobNames(plan.Foreign), ", "),
+		)
+	}
 	log.Info(
 		"Found Jobs owned by CronJob",
-		"cronJob", cleaner.Spec.CronJobName,
-		"count", len(ownedJobs),
+		"cronJob", strings.Join(cleaner.Spec.CronJobNames, ","),
+		"count", plan.Owned(),
 	)
-
-	activeJobs, succeededJobs, failedJobs := classifyJobs(ownedJobs)
 	log.Info(
 		"Job classification",
-		"active", len(activeJobs),
-		"succeeded", len(succeededJobs),
-		"failed", len(failedJobs),
+		"active", len(plan.Active),
+		"succeeded", len(plan.Succeeded),
+		"failed", len(plan.Failed),
 	)
 
-	stuckJobs := []batchv1.Job{}
-	deletedCount := 0
-
-	if cleaner.Spec.CleanupStuck.Enabled {
-		now := time.Now()
-		stuckAfter := cleaner.Spec.CleanupStuck.StuckAfter.Duration
-		stuckJobs = detectStuckJobs(activeJobs, stuckAfter, now)
+	planCtx, span := r.startSpan(ctx, spanPlan)
+	// A dry run leaves the Jobs it would not delete to the TTL controller
+	if cleaner.Spec.AdoptTTLJobs && !cleaner.Spec.ReadOnly {
+		adoptSucceeded := !dryRunFor(&cleaner.Spec, "succeeded")
+		adoptFailed := !dryRunFor(&cleaner.Spec, "failed")
+		if adoptSucceeded && adoptFailed {
+			r.adoptTTLJobs(planCtx, plan.Active)
+		}
+		if adoptSucceeded {
+			r.adoptTTLJobs(planCtx, plan.Succeeded)
+		}
+		if adoptFailed {
+			r.adoptTTLJobs(planCtx, plan.Failed)
+		}
+	}
 
+	if cleaner.Spec.CleanupStuck.Enabled && cleaner.Spec.CleanupStuck.UsePodConditionAge {
+		plan.Stuck = r.filterJobsWithStalePodConditions(planCtx, plan.Stuck, cleaner.Spec.CleanupStuck.StuckAfter.Duration, now)
+	}
+	if cleaner.Spec.CleanupStuck.Enabled && cleaner.Spec.CleanupStuck.RequirePodProgressStall {
+		plan.Stuck = r.filterStalledJobs(planCtx, plan.Stuck)
+	}
+	if cleaner.Spec.CleanupStuck.Enabled && cleaner.Spec.CleanupStuck.ExcludeWithinStartupProbe {
+		plan.Stuck = r.dropJobsWithinStartupProbe(planCtx, plan.Stuck, now)
+	}
+	if cleaner.Spec.CleanupStuck.Enabled && cleaner.Spec.CleanupStuck.IncludeUnschedulable {
+		candidates := withoutJobs(withoutJobs(plan.Active, plan.Stuck), plan.Abandoned)
+		if cleaner.Spec.CleanupStuck.Action == lifecyclev1alpha1.StuckActionQuarantine {
+			candidates = dropQuarantinedJobs(candidates, cleaner.Spec.CleanupStuck.QuarantineLabel)
+		}
+		plan.Stuck = append(plan.Stuck,
+			r.findUnschedulableJobs(planCtx, candidates, cleaner.Spec.CleanupStuck.StuckAfter.Duration, now)...)
+	}
+	allStuck := append(slices.Clone(plan.Stuck), plan.Abandoned...)
+	cleaner.Status.StuckJobs = len(allStuck)
+	cleaner.Status.StuckJobNames = nil
+	if len(allStuck) > 0 {
+		cleaner.Status.StuckJobNames = jobNames(allStuck)
+	}
+	if cleaner.Spec.CleanupStuck.ReportOnly {
+		if len(allStuck) > 0 {
+			r.Recorder.Eventf(
+				&cleaner,
+				corev1.EventTypeWarning,
+				eventReason(&cleaner.Spec, "StuckJobsDetected"),
+				"%d stuck Jobs detected, not deleting in report-only mode: %s",
+				len(allStuck),
+				strings.Join(cleaner.Status.StuckJobNames, ", "),
+			)
+		}
+		plan.Stuck = nil
+		plan.Abandoned = nil
+	}
+	if cleaner.Spec.RespectPVCReferences {
+		plan.Stuck = recordSkipped("pvc-reference", plan.Stuck, r.dropJobsHoldingPVCs(planCtx, plan.Stuck))
+		plan.Abandoned = recordSkipped("pvc-reference", plan.Abandoned, r.dropJobsHoldingPVCs(planCtx, plan.Abandoned))
+		plan.ExcessSucceeded = recordSkipped("pvc-reference", plan.ExcessSucceeded,
+			r.dropJobsHoldingPVCs(planCtx, plan.ExcessSucceeded))
+		plan.ExcessFailed = recordSkipped("pvc-reference", plan.ExcessFailed, r.dropJobsHoldingPVCs(planCtx, plan.ExcessFailed))
+	}
+	if codes := cleaner.Spec.Retain.KeepFailedExitCodes; len(codes) > 0 {
+		plan.ExcessFailed = recordSkipped("kept-exit-code", plan.ExcessFailed,
+			r.dropJobsWithKeptExitCodes(planCtx, plan.ExcessFailed, codes))
+	}
+	if cleaner.Spec.RespectVolumeDetach {
+		plan.ExcessSucceeded = recordSkipped("volume-attached", plan.ExcessSucceeded,
+			r.dropJobsAwaitingVolumeDetach(planCtx, plan.ExcessSucceeded))
+		plan.ExcessFailed = recordSkipped("volume-attached", plan.ExcessFailed,
+			r.dropJobsAwaitingVolumeDetach(planCtx, plan.ExcessFailed))
+	}
+	if annotation := cleaner.Spec.Retain.RequireLogsShippedAnnotation; annotation != "" {
+		plan.ExcessSucceeded = recordSkipped("logs-not-shipped", plan.ExcessSucceeded,
+			r.dropJobsWithUnshippedLogs(planCtx, plan.ExcessSucceeded, annotation))
+		plan.ExcessFailed = recordSkipped("logs-not-shipped", plan.ExcessFailed,
+			r.dropJobsWithUnshippedLogs(planCtx, plan.ExcessFailed, annotation))
+	}
+	span.End()
+	cleaner.Status.FailureRatio = strconv.FormatFloat(plan.FailureRatio, 'f', 2, 64)
+	cleaner.Status.OwnedJobCountDelta = 0
+	if cleaner.Status.LastEvaluatedTime != nil {
+		cleaner.Status.OwnedJobCountDelta = plan.Owned() - cleaner.Status.OwnedJobCount
+	}
+	cleaner.Status.OwnedJobCount = plan.Owned()
+	cleaner.Status.AlreadyTerminating = plan.Skipped["terminating"]
+
+	deletedJobs := []batchv1.Job{}
+	servicesDeleted := 0
+	throttled := false
+	quarantinedCount := 0
+	deletedByReason := map[string]int{}
+	budget := newDeletionBudget(cleaner.Spec.MaxDeletionsPerRun)
+
+	// Deletions count against the ceiling from the last spec change
+	if cleaner.Status.ObservedGeneration != cleaner.Generation {
+		cleaner.Status.DeletionsSinceSpecChange = 0
+	}
+	budget.total = lifetimeDeletionsLeft(&cleaner)
+	if budget.total == 0 {
 		log.Info(
-			"Stuck job detection",
-			"enabled", true,
-			"stuckAfter", stuckAfter.String(),
-			"count", len(stuckJobs),
+			"Lifetime deletion ceiling reached, not deleting until the spec changes",
+			"ceiling", cleaner.Spec.LifetimeDeletionCeiling,
 		)
-		deletedCount += r.deleteJobs(ctx, stuckJobs, "stuck")
-
-		// Retention logic for succeeded jobs
-		excessSucceeded := excessJobs(succeededJobs, cleaner.Spec.Retain.SuccessfulJobs)
+	}
 
+	warmingUp := warmupPending(&cleaner, now)
+	if warmingUp {
 		log.Info(
-			"Succeeded job retention evaluation",
-			"retain", cleaner.Spec.Retain.SuccessfulJobs,
-			"total", len(succeededJobs),
-			"excess", len(excessSucceeded),
+			"Warm-up in progress, deferring deletions",
+			"warmupStartedAt", cleaner.Status.WarmupStartedAt.Time,
+			"warmupPeriod", cleaner.Spec.WarmupPeriod.Duration.String(),
 		)
-		deletedCount += r.deleteJobs(ctx, excessSucceeded, "succeeded")
-
-		// Retention logic for failed jobs
-		excessFailed := excessJobs(failedJobs, cleaner.Spec.Retain.FailedJobs)
+	}
 
+	if cleaner.Spec.CleanupStuck.Enabled {
 		log.Info(
-			"Failed job retention evaluation",
-			"retain", cleaner.Spec.Retain.FailedJobs,
-			"total", len(failedJobs),
-			"excess", len(excessFailed),
+			"Stuck job detection",
+			"enabled", true,
+			"stuckAfter", cleaner.Spec.CleanupStuck.StuckAfter.Duration.String(),
+			"count", len(plan.Stuck),
+			"abandoned", len(plan.Abandoned),
 		)
-		deletedCount += r.deleteJobs(ctx, excessFailed, "failed")
-
-		if deletedCount > 0 {
-			now := metav1.Now()
-
-			cleaner.Status.LastRunTime = &now
-			cleaner.Status.JobsDeleted += deletedCount
-			cleaner.Status.PodsDeleted += deletedCount // 1 pod per job in our setup
+	}
+	log.Info(
+		"Succeeded job retention evaluation",
+		"retain", cleaner.Spec.Retain.SuccessfulJobs,
+		"total", len(plan.Succeeded),
+		"excess", len(plan.ExcessSucceeded),
+	)
+	log.Info(
+		"Failed job retention evaluation",
+		"retain", plan.RetainFailed,
+		"failureRatio", cleaner.Status.FailureRatio,
+		"total", len(plan.Failed),
+		"excess", len(plan.ExcessFailed),
+	)
 
-			if err := r.Status().Update(ctx, &cleaner); err != nil {
-				log.Error(err, "Failed to update CronExecutionCleaner status")
-				return ctrl.Result{}, err
-			}
+	cleaner.Status.JobsWouldDelete = 0
+	refillDeletionTokens(&cleaner, now)
+	if !warmingUp {
+		deleteCtx, span := r.startSpan(ctx, spanDelete)
+		var deleted []batchv1.Job
+		var quarantined int
+		deleted, throttled = r.deleteJobs(deleteCtx, &cleaner, budget.take(leftovers), "leftover")
+		deletedJobs = append(deletedJobs, deleted...)
+		deletedByReason["leftover"] += len(deleted)
+		if cleaner.Spec.CleanupStuck.Enabled && !throttled {
+			deleted, quarantined, throttled = r.handleStuckJobs(
+				deleteCtx, &cleaner, plan.Abandoned, cleaner.Spec.CleanupStuck.MaxAgeAction, "abandoned", budget,
+			)
+			deletedJobs = append(deletedJobs, deleted...)
+			deletedByReason["abandoned"] += len(deleted)
+			quarantinedCount += quarantined
+		}
+		// Once the API server keeps throttling, the rest waits for the next
+		// pass
+		if cleaner.Spec.CleanupStuck.Enabled && !throttled {
+			deleted, quarantined, throttled = r.handleStuckJobs(
+				deleteCtx, &cleaner, plan.Stuck, cleaner.Spec.CleanupStuck.Action, "stuck", budget,
+			)
+			deletedJobs = append(deletedJobs, deleted...)
+			deletedByReason["stuck"] += len(deleted)
+			quarantinedCount += quarantined
 		}
-		log.Info("Cleanup summary", "totalDeleted", deletedCount)
+		if !throttled {
+			deleted, throttled = r.deleteJobs(deleteCtx, &cleaner, budget.take(plan.ExcessSucceeded), "succeeded")
+			deletedJobs = append(deletedJobs, deleted...)
+			deletedByReason["succeeded"] += len(deleted)
+		}
+		if !throttled {
+			deleted, throttled = r.deleteJobs(deleteCtx, &cleaner, budget.take(plan.ExcessFailed), "failed")
+			deletedJobs = append(deletedJobs, deleted...)
+			deletedByReason["failed"] += len(deleted)
+		}
+		if cleaner.Spec.CleanupAssociatedServices && !cleaner.Spec.ReadOnly {
+			servicesDeleted = r.deleteAssociatedServices(deleteCtx, deletedJobs)
+		}
+		span.End()
 	}
+
+	if wouldDelete := cleaner.Status.JobsWouldDelete; wouldDelete > 0 {
+		r.Recorder.Eventf(
+			&cleaner,
+			corev1.EventTypeNormal,
+			"JobsDeleted"+dryRunReasonSuffix,
+			"Would delete %d Jobs, not deleting in dry run",
+			wouldDelete,
+		)
+	}
+	if deletedCount := len(deletedJobs); deletedCount > 0 {
+		runTime := metav1.NewTime(now)
+
+		cleaner.Status.LastRunTime = &runTime
+		recordDeletions(&cleaner.Status, deletedCount, now)
+		cleaner.Status.ServicesDeleted += servicesDeleted
+		cleaner.Status.ReclaimedResources = addResources(
+			cleaner.Status.ReclaimedResources,
+			sumJobResourceRequests(deletedJobs),
+		)
+	}
+	log.Info("Cleanup summary", "totalDeleted", len(deletedJobs))
 	setCondition(
 		&cleaner,
 		"Ready",
@@ -182,18 +644,161 @@ func (r *CronExecutionCleanerReconciler) Reconcile(ctx context.Context, req ctrl
 		"ReconcileSuccess",
 		"Cleanup executed successfully",
 	)
+	recordSuccess(&cleaner)
+	meta.RemoveStatusCondition(&cleaner.Status.Conditions, "ReconcilePanic")
+	meta.RemoveStatusCondition(&cleaner.Status.Conditions, "Degraded")
+	if lifetimeDeletionsLeft(&cleaner) == 0 {
+		setCondition(
+			&cleaner,
+			"CeilingReached",
+			metav1.ConditionTrue,
+			"LifetimeDeletionCeiling",
+			fmt.Sprintf(
+				"Deleted %d Jobs since the spec last changed, review and update the spec to resume deletions",
+				cleaner.Status.DeletionsSinceSpecChange,
+			),
+		)
+	} else {
+		meta.RemoveStatusCondition(&cleaner.Status.Conditions, "CeilingReached")
+	}
+	if recordStarvation(&cleaner, budget.capped) {
+		setCondition(
+			&cleaner,
+			"Starved",
+			metav1.ConditionTrue,
+			"DeletionCapReached",
+			fmt.Sprintf(
+				"Hit the cap of %d deletions per run for %d runs in a row while owned Jobs did not decrease, "+
+					"raise spec.maxDeletionsPerRun or shorten spec.runInterval",
+				cleaner.Spec.MaxDeletionsPerRun,
+				cleaner.Status.StarvedRuns,
+			),
+		)
+	} else {
+		meta.RemoveStatusCondition(&cleaner.Status.Conditions, "Starved")
+	}
+	cleaner.Status.Phase = lifecyclev1alpha1.PhaseIdle
+	if len(deletedJobs) > 0 {
+		cleaner.Status.Phase = lifecyclev1alpha1.PhaseCleaning
+	}
+	evaluatedAt := metav1.NewTime(now)
+	cleaner.Status.LastEvaluatedTime = &evaluatedAt
+	cleaner.Status.ObservedGeneration = cleaner.Generation
+	selected := len(leftovers) + len(plan.Stuck) + len(plan.Abandoned) + len(plan.ExcessSucceeded) + len(plan.ExcessFailed)
+	skipped := selected - len(deletedJobs) - quarantinedCount
+	outcome.Deleted = len(deletedJobs)
+	outcome.DeletedByReason = deletedByReason
+	outcome.Quarantined = quarantinedCount
+	outcome.Skipped = skipped
+	cleaner.Status.Message = runMessage(deletedByReason, quarantinedCount, skipped, now)
+	cleaner.Status.EstimatedDrainTime = nil
+	if budget.capped && !throttled {
+		cleaner.Status.EstimatedDrainTime = estimateDrainTime(&cleaner, skipped, now)
+	}
+	cleaner.Status.NextEligibleTime = nil
+	if cleaner.Spec.RequeueOnEligibility {
+		if next := nextEligibleTime(&cleaner.Spec, plan, now); next != nil {
+			eligibleAt := metav1.NewTime(*next)
+			cleaner.Status.NextEligibleTime = &eligibleAt
+		}
+	}
 
-	_ = r.Status().Update(ctx, &cleaner)
+	// A throttled run is retried shortly rather than after the run interval
+	cleaner.Status.RetryTime = nil
+	if throttled {
+		retryAt := metav1.NewTime(now.Add(min(throttledRequeueAfter, requeueInterval(&cleaner))))
+		cleaner.Status.RetryTime = &retryAt
+	}
+
+	r.updateStatus(ctx, &cleaner, observed)
 
+	// The webhook only hears about complete batches: every Job selected
+	// this run was deleted or quarantined
+	if cleaner.Spec.PostCleanupWebhook != "" && len(deletedJobs) > 0 && skipped == 0 && !throttled {
+		r.notifyPostCleanup(ctx, &cleaner, deletedJobs, deletedByReason, now)
+	}
+
+	if cleaner.Spec.WriteSummaryAnnotation {
+		r.writeSummaryAnnotation(ctx, &cleaner, runSummary{
+			Time:    evaluatedAt,
+			Deleted: len(deletedJobs),
+			Skipped: skipped,
+		})
+	}
+	if cleaner.Spec.AnnotateTargetCronJob && !cleaner.Spec.ReadOnly && len(deletedJobs) > 0 {
+		r.annotateTargetCronJob(ctx, cronJob, cleanupSummary{
+			Time:     evaluatedAt,
+			Cleaner:  cleaner.Name,
+			Deleted:  len(deletedJobs),
+			ByReason: deletedByReason,
+			Skipped:  skipped,
+		})
+	}
+
+	if throttled {
+		r.Recorder.Event(
+			&cleaner,
+			corev1.EventTypeWarning,
+			"DeletionsThrottled",
+			"API server kept throttling deletions, remaining Jobs are retried shortly",
+		)
+		return ctrl.Result{
+			RequeueAfter: cleaner.Status.RetryTime.Sub(now),
+		}, nil
+	}
+
+	if targetMissing {
+		log.Info("Target CronJob not found, backing off", "requeueAfter", missingTargetRequeue(&cleaner).String())
+		return ctrl.Result{
+			RequeueAfter: missingTargetRequeue(&cleaner),
+		}, nil
+	}
+
+	requeueAfter := requeueInterval(&cleaner)
+	if next := cleaner.Status.NextEligibleTime; next != nil {
+		requeueAfter = min(requeueAfter, next.Sub(now))
+	}
+	if windowCloses != nil {
+		requeueAfter = min(requeueAfter, windowCloses.Sub(now))
+	}
 	return ctrl.Result{
-		RequeueAfter: cleaner.Spec.RunInterval.Duration,
+		RequeueAfter: requeueAfter,
 	}, nil
 }
 
+// now returns the current time from the configured clock.
+func (r *CronExecutionCleanerReconciler) now() time.Time {
+	if r.Clock == nil {
+		return time.Now()
+	}
+	return r.Clock.Now()
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *CronExecutionCleanerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.Recorder = mgr.GetEventRecorderFor("cronexecutioncleaner")
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&lifecyclev1alpha1.CronExecutionCleaner{}).
-		Complete(r)
+	r.Client = newCountingClient(r.Client)
+	if r.APIReader == nil {
+		r.APIReader = mgr.GetAPIReader()
+	}
+	r.APIReader = newCountingReader(r.APIReader)
+	for _, p := range r.partitions() {
+		b := ctrl.NewControllerManagedBy(mgr).Named(p.name)
+		if r.PrioritizeFailing {
+			b = b.Watches(&lifecyclev1alpha1.CronExecutionCleaner{}, priorityEnqueuer{},
+				builder.WithPredicates(r.partitionPredicate(p)))
+		} else {
+			b = b.For(&lifecyclev1alpha1.CronExecutionCleaner{}, builder.WithPredicates(r.partitionPredicate(p)))
+		}
+		if r.PauseConfigMap.Name != "" {
+			b = b.Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.enqueueAllCleaners))
+		}
+		err := b.
+			WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
+			Complete(partitionReconciler{CronExecutionCleanerReconciler: r, partition: p})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
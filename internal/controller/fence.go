@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"context"
+	"sync"
+)
+
+// NamespaceFence bounds the number of Job deletions in flight per namespace,
+// across all cleaners, so that a busy namespace does not exceed its API
+// priority-and-fairness share. A nil fence lets every deletion through.
+type NamespaceFence struct {
+	limit int
+
+	mu    sync.Mutex
+	slots map[string]chan struct{}
+}
+
+// NewNamespaceFence returns a fence allowing at most limit concurrent
+// deletions in any one namespace, or nil if limit is not positive.
+func NewNamespaceFence(limit int) *NamespaceFence {
+	if limit <= 0 {
+		return nil
+	}
+	return &NamespaceFence{limit: limit, slots: map[string]chan struct{}{}}
+}
+
+// acquire waits for a free deletion slot in the namespace and returns the
+// function that gives it back. It fails only if the context is done first.
+func (f *NamespaceFence) acquire(ctx context.Context, namespace string) (func(), error) {
+	if f == nil {
+		return func() {}, nil
+	}
+	f.mu.Lock()
+	slots, ok := f.slots[namespace]
+	if !ok {
+		slots = make(chan struct{}, f.limit)
+		f.slots[namespace] = slots
+	}
+	f.mu.Unlock()
+
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -2,14 +2,29 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"runtime/debug"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	lifecyclev1alpha1 "github.com/bhatpriyanka8/cron-execution-cleaner/api/v1alpha1"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -21,12 +36,66 @@ func validateSpec(ctx context.Context, cleaner *lifecyclev1alpha1.CronExecutionC
 		return fmt.Errorf("spec.runInterval must be at least 1s")
 	}
 
-	// Validate Retention Policy is non-negative
-	if cleaner.Spec.Retain.SuccessfulJobs < 0 {
-		return fmt.Errorf("spec.retain.successfulJobs cannot be negative")
+	// Validate the target namespace is set
+	if cleaner.Spec.Namespace == "" {
+		return fmt.Errorf("spec.namespace must be set")
 	}
-	if cleaner.Spec.Retain.FailedJobs < 0 {
-		return fmt.Errorf("spec.retain.failedJobs cannot be negative")
+
+	// Validate Jobs are targeted by CronJob name, by selector or both
+	names := targetCronJobNames(&cleaner.Spec)
+	if selector := cleaner.Spec.Selector; selector != nil {
+		parsed, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			return fmt.Errorf("spec.selector is not a valid label selector: %w", err)
+		}
+		if parsed.Empty() && len(names) == 0 {
+			return fmt.Errorf("spec.selector cannot be empty without spec.cronJobName")
+		}
+		if cleaner.Spec.UseJobTemplateLabels {
+			return fmt.Errorf("spec.selector cannot be combined with spec.useJobTemplateLabels")
+		}
+	} else if len(names) == 0 {
+		return fmt.Errorf("spec.cronJobName or spec.cronJobNames must be set")
+	}
+	if cleaner.Spec.UseJobTemplateLabels && len(names) > 1 {
+		return fmt.Errorf("spec.useJobTemplateLabels supports a single target CronJob only")
+	}
+
+	// Validate the maintenance window is a time of day and a duration that
+	// fits in a day
+	if window := cleaner.Spec.MaintenanceWindow; window != nil {
+		if _, err := time.Parse(maintenanceWindowLayout, window.Start); err != nil {
+			return fmt.Errorf("spec.maintenanceWindow.start must be a time of day as HH:MM")
+		}
+		if d := window.Duration.Duration; d <= 0 || d > 24*time.Hour {
+			return fmt.Errorf("spec.maintenanceWindow.duration must be greater than 0 and at most 24h")
+		}
+	}
+
+	// Validate the post-cleanup webhook is an absolute HTTP(S) URL
+	if webhook := cleaner.Spec.PostCleanupWebhook; webhook != "" {
+		u, err := url.Parse(webhook)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("spec.postCleanupWebhook must be an http or https URL")
+		}
+	}
+
+	// Validate Retention Policy is non-negative, or the keep-all sentinel
+	if cleaner.Spec.Retain.SuccessfulJobs < lifecyclev1alpha1.RetainAll {
+		return fmt.Errorf("spec.retain.successfulJobs cannot be negative, except -1 to retain all")
+	}
+	if cleaner.Spec.Retain.FailedJobs < lifecyclev1alpha1.RetainAll {
+		return fmt.Errorf("spec.retain.failedJobs cannot be negative, except -1 to retain all")
+	}
+	if cleaner.Spec.Retain.ManualJobs != nil && *cleaner.Spec.Retain.ManualJobs < lifecyclev1alpha1.RetainAll {
+		return fmt.Errorf("spec.retain.manualJobs cannot be negative, except -1 to retain all")
+	}
+	// Validate retention TTLs are at least 1 second or more
+	if ttl := cleaner.Spec.Retain.SuccessfulTTL; ttl != nil && ttl.Duration < time.Second {
+		return fmt.Errorf("spec.retain.successfulTTL must be at least 1s")
+	}
+	if ttl := cleaner.Spec.Retain.FailedTTL; ttl != nil && ttl.Duration < time.Second {
+		return fmt.Errorf("spec.retain.failedTTL must be at least 1s")
 	}
 	// Validate Cleanup Stuck Policy if enabled, is at least 1 second or more
 	if cleaner.Spec.CleanupStuck.Enabled &&
@@ -34,9 +103,238 @@ func validateSpec(ctx context.Context, cleaner *lifecyclev1alpha1.CronExecutionC
 		return fmt.Errorf("spec.cleanupStuck.stuckAfter must be at least 1s when enabled")
 
 	}
+	// Validate missing target requeue is at least 1 second or more
+	if d := cleaner.Spec.MissingTargetRequeue; d != nil && d.Duration < time.Second {
+		return fmt.Errorf("spec.missingTargetRequeue must be at least 1s")
+	}
+	// Validate failure ratio threshold parses as a ratio
+	if cleaner.Spec.Retain.ElevateThresholdRatio != "" {
+		ratio, err := strconv.ParseFloat(cleaner.Spec.Retain.ElevateThresholdRatio, 64)
+		if err != nil || ratio < 0 || ratio > 1 {
+			return fmt.Errorf("spec.retain.elevateThresholdRatio must be a number between 0 and 1")
+		}
+	}
+	// Validate stuck actions are known
+	switch cleaner.Spec.CleanupStuck.Action {
+	case "", lifecyclev1alpha1.StuckActionDelete, lifecyclev1alpha1.StuckActionQuarantine:
+	default:
+		return fmt.Errorf("spec.cleanupStuck.action must be delete or quarantine")
+	}
+	switch cleaner.Spec.CleanupStuck.MaxAgeAction {
+	case "", lifecyclev1alpha1.StuckActionDelete, lifecyclev1alpha1.StuckActionQuarantine:
+	default:
+		return fmt.Errorf("spec.cleanupStuck.maxAgeAction must be delete or quarantine")
+	}
+	// Validate deletion propagation is supported
+	switch cleaner.Spec.DeletePropagation {
+	case "", metav1.DeletePropagationBackground, metav1.DeletePropagationForeground:
+	default:
+		return fmt.Errorf("spec.deletePropagation must be Background or Foreground")
+	}
+	// Validate abandoned job age is at least 1 second or more
+	if maxAge := cleaner.Spec.CleanupStuck.MaxAge; maxAge != nil && maxAge.Duration < time.Second {
+		return fmt.Errorf("spec.cleanupStuck.maxAge must be at least 1s")
+	}
+	// Validate fast retention is non-negative
+	if cleaner.Spec.Retain.FastRetain != nil && *cleaner.Spec.Retain.FastRetain < 0 {
+		return fmt.Errorf("spec.retain.fastRetain cannot be negative")
+	}
+	// Validate weekday overrides name distinct weekdays and keep a valid count
+	days := make([]string, 0, len(cleaner.Spec.Retain.WeekdayOverrides))
+	for day := range cleaner.Spec.Retain.WeekdayOverrides {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+	seenDays := map[time.Weekday]string{}
+	for _, day := range days {
+		weekday, ok := parseWeekday(day)
+		if !ok {
+			return fmt.Errorf("spec.retain.weekdayOverrides has unknown weekday %q", day)
+		}
+		if other, ok := seenDays[weekday]; ok {
+			return fmt.Errorf("spec.retain.weekdayOverrides has both %q and %q for %s", other, day, weekday)
+		}
+		seenDays[weekday] = day
+		if cleaner.Spec.Retain.WeekdayOverrides[day] < lifecyclev1alpha1.RetainAll {
+			return fmt.Errorf("spec.retain.weekdayOverrides cannot be negative, except -1 to retain all")
+		}
+	}
+	// Validate per-day retention is non-negative
+	if cleaner.Spec.Retain.PerDay < 0 || cleaner.Spec.Retain.DaysToKeep < 0 {
+		return fmt.Errorf("spec.retain.perDay and spec.retain.daysToKeep cannot be negative")
+	}
+	// Validate name exclusion compiles
+	if _, err := regexp.Compile(cleaner.Spec.ExcludeNameRegex); err != nil {
+		return fmt.Errorf("spec.excludeNameRegex is not a valid regular expression: %w", err)
+	}
+	// Validate protection expression compiles
+	if expr := cleaner.Spec.ProtectExpression; expr != "" {
+		if _, err := compileProtectExpression(expr); err != nil {
+			return fmt.Errorf("spec.protectExpression does not compile: %w", err)
+		}
+	}
+	// Validate lifetime deletion ceiling is non-negative
+	if cleaner.Spec.LifetimeDeletionCeiling < 0 {
+		return fmt.Errorf("spec.lifetimeDeletionCeiling cannot be negative")
+	}
+	// Validate starvation threshold is non-negative
+	if cleaner.Spec.StarvationThreshold < 0 {
+		return fmt.Errorf("spec.starvationThreshold cannot be negative")
+	}
+	// Validate per-run deletion cap is non-negative
+	if cleaner.Spec.MaxDeletionsPerRun < 0 {
+		return fmt.Errorf("spec.maxDeletionsPerRun cannot be negative")
+	}
+	// Validate deletion rate limit allows at least one deletion per minute
+	if limit := cleaner.Spec.DeletionRateLimit; limit != nil {
+		if limit.PerMinute < 1 {
+			return fmt.Errorf("spec.deletionRateLimit.perMinute must be at least 1")
+		}
+		if limit.Burst < 0 {
+			return fmt.Errorf("spec.deletionRateLimit.burst cannot be negative")
+		}
+	}
 	return nil
 }
 
+// EffectiveSpec returns the spec the controller acts on once all defaults
+// have been resolved. The cleaner itself is not modified.
+func EffectiveSpec(cleaner *lifecyclev1alpha1.CronExecutionCleaner) lifecyclev1alpha1.CronExecutionCleanerSpec {
+	spec := *cleaner.Spec.DeepCopy()
+
+	spec.CronJobNames = targetCronJobNames(&spec)
+	if spec.CronJobName == "" && len(spec.CronJobNames) > 0 {
+		spec.CronJobName = spec.CronJobNames[0]
+	}
+	if spec.Retain.DaysToKeep > 0 && spec.Retain.PerDay == 0 {
+		spec.Retain.PerDay = 1
+	}
+	if spec.CleanupStuck.Action == "" {
+		spec.CleanupStuck.Action = lifecyclev1alpha1.StuckActionDelete
+	}
+	if spec.CleanupStuck.MaxAgeAction == "" {
+		spec.CleanupStuck.MaxAgeAction = spec.CleanupStuck.Action
+	}
+	if spec.CleanupStuck.QuarantineLabel == "" {
+		spec.CleanupStuck.QuarantineLabel = lifecyclev1alpha1.DefaultQuarantineLabel
+	}
+	if len(spec.OwnerKinds) == 0 {
+		spec.OwnerKinds = []string{"CronJob"}
+	}
+	if spec.Retain.ElevateThresholdRatio != "" && spec.Retain.ElevateFactor == 0 {
+		spec.Retain.ElevateFactor = 2
+	}
+	return spec
+}
+
+// targetCronJobNames returns the names of the CronJobs the cleaner targets:
+// cronJobName, if set, followed by cronJobNames, without duplicates.
+func targetCronJobNames(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) []string {
+	names := []string{}
+	for _, name := range append([]string{spec.CronJobName}, spec.CronJobNames...) {
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// jobNames returns the names of the given Jobs.
+func jobNames(jobs []batchv1.Job) []string {
+	names := []string{}
+	for _, job := range jobs {
+		names = append(names, job.Name)
+	}
+	return names
+}
+
+// dropJobsMatchingName drops Jobs whose names match the expression.
+func dropJobsMatchingName(jobs []batchv1.Job, re *regexp.Regexp) []batchv1.Job {
+	kept := []batchv1.Job{}
+	for _, job := range jobs {
+		if !re.MatchString(job.Name) {
+			kept = append(kept, job)
+		}
+	}
+	return kept
+}
+
+// jobTemplateLabels returns the labels the CronJob copies onto its Jobs.
+func jobTemplateLabels(cronJob *batchv1.CronJob) map[string]string {
+	if cronJob == nil {
+		return nil
+	}
+	return cronJob.Spec.JobTemplate.Labels
+}
+
+// parseWeekday parses an English weekday name, ignoring case.
+func parseWeekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(name, day.String()) {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+// applyWeekdayRetention switches the successful retention count to the
+// override for the current weekday (UTC), if any. It reports whether an
+// override was applied.
+func applyWeekdayRetention(spec *lifecyclev1alpha1.CronExecutionCleanerSpec, now time.Time) bool {
+	today := now.UTC().Weekday()
+	for name, count := range spec.Retain.WeekdayOverrides {
+		if day, ok := parseWeekday(name); ok && day == today {
+			spec.Retain.SuccessfulJobs = count
+			return true
+		}
+	}
+	return false
+}
+
+// applyFastRetention switches the retention counts to the fast retention
+// count when the target CronJob asks for fast cleanup. It reports whether the
+// fast retention was applied.
+func applyFastRetention(spec *lifecyclev1alpha1.CronExecutionCleanerSpec, cronJob *batchv1.CronJob) bool {
+	if cronJob == nil || spec.Retain.FastRetain == nil {
+		return false
+	}
+	if _, ok := cronJob.Annotations[lifecyclev1alpha1.FastCleanupAnnotation]; !ok {
+		return false
+	}
+
+	spec.Retain.SuccessfulJobs = *spec.Retain.FastRetain
+	spec.Retain.FailedJobs = *spec.Retain.FastRetain
+	return true
+}
+
+// Job history limits the API server defaults an unset CronJob field to
+const (
+	defaultSuccessfulJobsHistoryLimit = 3
+	defaultFailedJobsHistoryLimit     = 1
+)
+
+// cronJobHistoryLimits returns the number of successful and failed Jobs the
+// CronJob controller keeps for the CronJob.
+func cronJobHistoryLimits(cronJob *batchv1.CronJob) (successful, failed int) {
+	successful, failed = defaultSuccessfulJobsHistoryLimit, defaultFailedJobsHistoryLimit
+	if limit := cronJob.Spec.SuccessfulJobsHistoryLimit; limit != nil {
+		successful = int(*limit)
+	}
+	if limit := cronJob.Spec.FailedJobsHistoryLimit; limit != nil {
+		failed = int(*limit)
+	}
+	return successful, failed
+}
+
+// stricterRetention returns the smaller of two retention counts, where
+// RetainAll is larger than any count.
+func stricterRetention(count, limit int) int {
+	if count == lifecyclev1alpha1.RetainAll || limit < count {
+		return limit
+	}
+	return count
+}
+
 func setCondition(
 	cleaner *lifecyclev1alpha1.CronExecutionCleaner,
 	conditionType string,
@@ -71,10 +369,151 @@ func detectStuckJobs(
 	return stuckJobs
 }
 
+// dropJobsWithinStartingDeadline removes the Jobs that started less than the
+// CronJob's startingDeadlineSeconds ago. Without a CronJob or a deadline all
+// Jobs are kept.
+func dropJobsWithinStartingDeadline(jobs []batchv1.Job, cronJob *batchv1.CronJob, now time.Time) []batchv1.Job {
+	if cronJob == nil || cronJob.Spec.StartingDeadlineSeconds == nil {
+		return jobs
+	}
+	grace := time.Duration(*cronJob.Spec.StartingDeadlineSeconds) * time.Second
+
+	kept := []batchv1.Job{}
+	for _, job := range jobs {
+		if job.Status.StartTime != nil && now.Sub(job.Status.StartTime.Time) < grace {
+			continue
+		}
+		kept = append(kept, job)
+	}
+	return kept
+}
+
+// podConditionsStale reports whether none of the Pods has had a condition
+// transition or probe within stuckAfter. A Pod without conditions counts
+// from its creation. Without Pods there is nothing to judge by.
+func podConditionsStale(pods []corev1.Pod, stuckAfter time.Duration, now time.Time) bool {
+	if len(pods) == 0 {
+		return false
+	}
+	for _, pod := range pods {
+		last := pod.CreationTimestamp.Time
+		for _, cond := range pod.Status.Conditions {
+			if cond.LastTransitionTime.After(last) {
+				last = cond.LastTransitionTime.Time
+			}
+			if cond.LastProbeTime.After(last) {
+				last = cond.LastProbeTime.Time
+			}
+		}
+		if now.Sub(last) <= stuckAfter {
+			return false
+		}
+	}
+	return true
+}
+
+// podUnschedulableFor reports whether the Pod has been unschedulable for
+// longer than stuckAfter.
+func podUnschedulableFor(pod *corev1.Pod, stuckAfter time.Duration, now time.Time) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodScheduled &&
+			cond.Status == corev1.ConditionFalse &&
+			cond.Reason == corev1.PodReasonUnschedulable {
+			return now.Sub(cond.LastTransitionTime.Time) > stuckAfter
+		}
+	}
+	return false
+}
+
+// withinStartupProbe reports whether a container of the Pod has a startup
+// probe that has neither succeeded nor used up its budget of initialDelay +
+// failureThreshold × period, counted from when the container started.
+func withinStartupProbe(pod *corev1.Pod, now time.Time) bool {
+	for _, container := range pod.Spec.Containers {
+		probe := container.StartupProbe
+		if probe == nil {
+			continue
+		}
+
+		var status *corev1.ContainerStatus
+		for i := range pod.Status.ContainerStatuses {
+			if pod.Status.ContainerStatuses[i].Name == container.Name {
+				status = &pod.Status.ContainerStatuses[i]
+				break
+			}
+		}
+		if status != nil && status.Started != nil && *status.Started {
+			continue
+		}
+
+		var started time.Time
+		switch {
+		case status != nil && status.State.Running != nil:
+			started = status.State.Running.StartedAt.Time
+		case pod.Status.StartTime != nil:
+			started = pod.Status.StartTime.Time
+		default:
+			// Not started at all, so the probe has not begun counting.
+			return true
+		}
+
+		period, threshold := probe.PeriodSeconds, probe.FailureThreshold
+		if period == 0 {
+			period = 10
+		}
+		if threshold == 0 {
+			threshold = 3
+		}
+		budget := time.Duration(probe.InitialDelaySeconds+threshold*period) * time.Second
+		if now.Sub(started) < budget {
+			return true
+		}
+	}
+	return false
+}
+
+// podStillStarting reports whether a Pod is still running init containers or
+// waiting on readiness gates, in which case its Job has not stalled yet.
+func podStillStarting(pod *corev1.Pod) bool {
+	for _, status := range pod.Status.InitContainerStatuses {
+		if status.State.Terminated == nil {
+			return true
+		}
+	}
+
+	for _, gate := range pod.Spec.ReadinessGates {
+		satisfied := false
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == gate.ConditionType && cond.Status == corev1.ConditionTrue {
+				satisfied = true
+				break
+			}
+		}
+		if !satisfied {
+			return true
+		}
+	}
+
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodInitialized && cond.Status != corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
 func excessJobs(
 	jobs []batchv1.Job,
 	retainCount int,
 ) []batchv1.Job {
+	if retainCount == lifecyclev1alpha1.RetainAll {
+		return []batchv1.Job{}
+	}
+
+	// Sort a copy so the caller's slice, which may be backed by the informer
+	// cache, keeps its original order.
+	jobs = slices.Clone(jobs)
+
 	// Sort jobs by start time in descending order (newest first)
 	sort.Slice(jobs, func(i, j int) bool {
 		if jobs[i].Status.StartTime == nil {
@@ -95,23 +534,178 @@ func excessJobs(
 	return []batchv1.Job{}
 }
 
-func filterJobsByOwner(jobs []batchv1.Job, cronJobName string) []batchv1.Job {
-	var ownedJobs []batchv1.Job
+// excessBatches is excessJobs for Jobs grouped into batches by the value of
+// the given label. The newest retainCount batches are kept, a batch being as
+// new as its newest Job, and every Job of the older batches is returned.
+func excessBatches(
+	jobs []batchv1.Job,
+	retainCount int,
+	label string,
+) []batchv1.Job {
+	if label == "" || retainCount == lifecyclev1alpha1.RetainAll {
+		return excessJobs(jobs, retainCount)
+	}
+
+	batchOf := func(job *batchv1.Job) string {
+		if value, ok := job.Labels[label]; ok {
+			return "batch/" + value
+		}
+		return "job/" + job.Name
+	}
+
+	// Newest first, so batches are met in order of their newest Job
+	sorted := excessJobs(jobs, 0)
+	kept := map[string]bool{}
+	excess := []batchv1.Job{}
+	for i := range sorted {
+		batch := batchOf(&sorted[i])
+		if !kept[batch] && len(kept) < retainCount {
+			kept[batch] = true
+		}
+		if !kept[batch] {
+			excess = append(excess, sorted[i])
+		}
+	}
+	return excess
+}
+
+// olderGenerationJobs returns the names of the Jobs whose generation, the
+// value of the given label, is older than the newest generation and the
+// keepPrevious generations before it. A generation is as new as its newest
+// Job, so all owned Jobs, active ones included, should be passed in to tell
+// which one is current. Jobs without the label are never included.
+func olderGenerationJobs(jobs []batchv1.Job, label string, keepPrevious int) map[string]bool {
+	// Newest first, so generations are met in order of their newest Job
+	sorted := excessJobs(jobs, 0)
+	kept := map[string]bool{}
+	older := map[string]bool{}
+	for _, job := range sorted {
+		generation, ok := job.Labels[label]
+		if !ok {
+			continue
+		}
+		if !kept[generation] && len(kept) <= keepPrevious {
+			kept[generation] = true
+		}
+		if !kept[generation] {
+			older[job.Name] = true
+		}
+	}
+	return older
+}
+
+// failureRatio returns the share of failed Jobs among completed Jobs.
+func failureRatio(succeeded, failed int) float64 {
+	if succeeded+failed == 0 {
+		return 0
+	}
+	return float64(failed) / float64(succeeded+failed)
+}
+
+// failedRetention returns the number of failed Jobs to keep, elevated by the
+// configured factor while the failure ratio is above the threshold.
+func failedRetention(retain lifecyclev1alpha1.RetentionPolicy, ratio float64) int {
+	if retain.ElevateThresholdRatio == "" || retain.FailedJobs == lifecyclev1alpha1.RetainAll {
+		return retain.FailedJobs
+	}
+
+	threshold, err := strconv.ParseFloat(retain.ElevateThresholdRatio, 64)
+	if err != nil || ratio <= threshold {
+		return retain.FailedJobs
+	}
+	return retain.FailedJobs * retain.ElevateFactor
+}
 
+// filterJobsByOwner returns the Jobs owned by an object of any of the given
+// kinds whose name is among the owners, grouped by owner name.
+//
+// Owner references carry no namespace, so an owner in another namespace, as
+// restored backups can produce, shows up as a reference whose UID differs
+// from the owner's. When an owner's UID is known, such Jobs are returned as
+// foreign instead of owned.
+func filterJobsByOwner(
+	jobs []batchv1.Job,
+	owners map[string]types.UID,
+	ownerKinds []string,
+	requireController bool,
+) (ownedJobs map[string][]batchv1.Job, foreignJobs []batchv1.Job) {
+	ownedJobs = map[string][]batchv1.Job{}
 	for _, job := range jobs {
 		for _, owner := range job.OwnerReferences {
-			if owner.Kind == "CronJob" && owner.Name == cronJobName {
-				ownedJobs = append(ownedJobs, job)
+			if requireController && (owner.Controller == nil || !*owner.Controller) {
+				continue
+			}
+			ownerUID, ok := owners[owner.Name]
+			if ok && slices.Contains(ownerKinds, owner.Kind) {
+				if ownerUID != "" && owner.UID != ownerUID {
+					foreignJobs = append(foreignJobs, job)
+				} else {
+					ownedJobs[owner.Name] = append(ownedJobs[owner.Name], job)
+				}
 				break
 			}
 		}
 	}
-	return ownedJobs
+	return ownedJobs, foreignJobs
+}
+
+// dropActiveJobs removes Jobs that still have active Pods. Retention must never
+// delete such a Job, even if it also reports completed Pods.
+func dropActiveJobs(jobs []batchv1.Job) []batchv1.Job {
+	inactive := []batchv1.Job{}
+	for _, job := range jobs {
+		if job.Status.Active > 0 {
+			continue
+		}
+		inactive = append(inactive, job)
+	}
+	return inactive
+}
+
+// partiallyComplete reports whether a Job with several completions has some
+// but not all of them, and has not finished yet. Its Pods may all be gone for a
+// moment, e.g. between a Pod finishing and the next one being created.
+func partiallyComplete(job *batchv1.Job) bool {
+	if job.Spec.Completions == nil || job.Status.Succeeded >= *job.Spec.Completions {
+		return false
+	}
+	return jobFinishedAt(job) == nil
+}
+
+// dropTerminatingJobs removes Jobs that already have a deletion timestamp.
+func dropTerminatingJobs(jobs []batchv1.Job) []batchv1.Job {
+	kept := []batchv1.Job{}
+	for _, job := range jobs {
+		if job.DeletionTimestamp == nil {
+			kept = append(kept, job)
+		}
+	}
+	return kept
+}
+
+// manualInstantiateAnnotation is set to "manual" by kubectl on Jobs created
+// from a CronJob with kubectl create job --from=cronjob/...
+const manualInstantiateAnnotation = "cronjob.kubernetes.io/instantiate"
+
+// splitManualJobs separates manually triggered Jobs from scheduled ones.
+func splitManualJobs(jobs []batchv1.Job) (scheduled, manual []batchv1.Job) {
+	for _, job := range jobs {
+		if job.Annotations[manualInstantiateAnnotation] == "manual" {
+			manual = append(manual, job)
+		} else {
+			scheduled = append(scheduled, job)
+		}
+	}
+	return scheduled, manual
 }
+
+// classifyJobs sorts Jobs into active, succeeded and failed. A Job that
+// needs several completions only counts as succeeded once all of them are
+// done.
 func classifyJobs(jobs []batchv1.Job) (active, succeeded, failed []batchv1.Job) {
 	for _, job := range jobs {
 		switch {
-		case job.Status.Active > 0:
+		case job.Status.Active > 0, partiallyComplete(&job):
 			active = append(active, job)
 
 		case job.Status.Succeeded > 0:
@@ -124,22 +718,1634 @@ func classifyJobs(jobs []batchv1.Job) (active, succeeded, failed []batchv1.Job)
 	return active, succeeded, failed
 }
 
-func (r *CronExecutionCleanerReconciler) deleteJobs(
-	ctx context.Context,
-	jobs []batchv1.Job,
-	jobType string,
-) int {
-	logger := ctrl.LoggerFrom(ctx)
-	deletedCount := 0
+// keptPerDay returns the names of the newest perDay Jobs completed on each of
+// the last daysToKeep calendar days (UTC). Jobs with an unknown completion
+// time are never kept by day.
+func keptPerDay(jobs []batchv1.Job, perDay, daysToKeep int, now time.Time) map[string]bool {
+	kept := map[string]bool{}
+	if daysToKeep <= 0 || perDay <= 0 {
+		return kept
+	}
 
-	policy := metav1.DeletePropagationBackground
+	day := func(t time.Time) time.Time {
+		y, m, d := t.UTC().Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	today := day(now)
+
+	buckets := map[int][]batchv1.Job{}
 	for _, job := range jobs {
-		logger.Info("Deleting job", "type", jobType, "job", job.Name)
-		if err := r.Delete(ctx, &job, &client.DeleteOptions{PropagationPolicy: &policy}); err != nil {
-			logger.Error(err, "Failed to delete job", "type", jobType, "job", job.Name)
+		finishedAt := jobFinishedAt(&job)
+		if finishedAt == nil {
+			continue
+		}
+		age := int(today.Sub(day(*finishedAt)).Hours() / 24)
+		if age < 0 || age >= daysToKeep {
+			continue
+		}
+		buckets[age] = append(buckets[age], job)
+	}
+
+	for _, bucket := range buckets {
+		sort.Slice(bucket, func(i, j int) bool {
+			return jobFinishedAt(&bucket[i]).After(*jobFinishedAt(&bucket[j]))
+		})
+		for i := 0; i < len(bucket) && i < perDay; i++ {
+			kept[bucket[i].Name] = true
+		}
+	}
+	return kept
+}
+
+// jobFinishedAt returns when a Job completed or failed, or nil when unknown.
+// A Job with active Pods has not finished, whatever else its status says, so
+// callers must treat nil as "not yet complete" rather than as very old.
+func jobFinishedAt(job *batchv1.Job) *time.Time {
+	if job.Status.Active > 0 {
+		return nil
+	}
+	if job.Status.CompletionTime != nil {
+		return &job.Status.CompletionTime.Time
+	}
+	for _, condition := range job.Status.Conditions {
+		if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) &&
+			condition.Status == corev1.ConditionTrue {
+			return &condition.LastTransitionTime.Time
+		}
+	}
+	return nil
+}
+
+// dropJobsInGrace removes Jobs that finished less than grace ago. Jobs with
+// an unknown finish time are kept.
+func dropJobsInGrace(jobs []batchv1.Job, grace *metav1.Duration, now time.Time) []batchv1.Job {
+	if grace == nil {
+		return jobs
+	}
+
+	settled := []batchv1.Job{}
+	for _, job := range jobs {
+		if finishedAt := jobFinishedAt(&job); finishedAt != nil && now.Sub(*finishedAt) < grace.Duration {
 			continue
 		}
-		deletedCount++
+		settled = append(settled, job)
+	}
+	return settled
+}
+
+// jobsOlderThan returns the Jobs that finished more than ttl ago. Jobs with
+// an unknown finish time, including those still running, are never
+// returned.
+func jobsOlderThan(jobs []batchv1.Job, ttl time.Duration, now time.Time) []batchv1.Job {
+	expired := []batchv1.Job{}
+	for _, job := range jobs {
+		if finishedAt := jobFinishedAt(&job); finishedAt != nil && now.Sub(*finishedAt) > ttl {
+			expired = append(expired, job)
+		}
+	}
+	return expired
+}
+
+// warmupPending reports whether the cleaner is still inside its one-time
+// warm-up period, recording the start of the period on first call.
+func warmupPending(cleaner *lifecyclev1alpha1.CronExecutionCleaner, now time.Time) bool {
+	if cleaner.Spec.WarmupPeriod == nil || cleaner.Status.WarmupComplete {
+		return false
+	}
+
+	if cleaner.Status.WarmupStartedAt == nil {
+		started := metav1.NewTime(now)
+		cleaner.Status.WarmupStartedAt = &started
+	}
+
+	if now.Sub(cleaner.Status.WarmupStartedAt.Time) < cleaner.Spec.WarmupPeriod.Duration {
+		return true
+	}
+
+	cleaner.Status.WarmupComplete = true
+	return false
+}
+
+// recordFailure counts a failed run and turns the Ready condition False once
+// the failures have lasted longer than the debounce or reached the configured
+// number of consecutive failures. Without either setting, Ready flips at once.
+func recordFailure(
+	cleaner *lifecyclev1alpha1.CronExecutionCleaner,
+	now time.Time,
+	reason, message string,
+) {
+	cleaner.Status.ConsecutiveFailures++
+	if cleaner.Status.FailingSince == nil {
+		since := metav1.NewTime(now)
+		cleaner.Status.FailingSince = &since
+	}
+
+	if !failurePersisted(cleaner, now) {
+		return
+	}
+
+	setCondition(cleaner, "Ready", metav1.ConditionFalse, reason, message)
+}
+
+// apiUnavailableThreshold is the number of consecutive failed runs, the last
+// one failing to reach the API server, after which the Degraded condition is
+// set.
+const apiUnavailableThreshold = 3
+
+// isConnectionError reports whether the error means the API server could not
+// be reached, as opposed to it rejecting the request.
+func isConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) || apierrors.IsServiceUnavailable(err) {
+		return true
+	}
+	if utilnet.IsConnectionRefused(err) || utilnet.IsConnectionReset(err) ||
+		utilnet.IsProbableEOF(err) || utilnet.IsTimeout(err) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// failurePersisted reports whether the current failure streak is long enough
+// to be surfaced on the Ready condition.
+func failurePersisted(cleaner *lifecyclev1alpha1.CronExecutionCleaner, now time.Time) bool {
+	spec := cleaner.Spec
+	if spec.ReadyDebounce == nil && spec.ReadyFailureThreshold == 0 {
+		return true
+	}
+
+	if spec.ReadyFailureThreshold > 0 &&
+		cleaner.Status.ConsecutiveFailures >= spec.ReadyFailureThreshold {
+		return true
+	}
+
+	return spec.ReadyDebounce != nil &&
+		now.Sub(cleaner.Status.FailingSince.Time) >= spec.ReadyDebounce.Duration
+}
+
+// recordSuccess ends the current failure streak.
+func recordSuccess(cleaner *lifecyclev1alpha1.CronExecutionCleaner) {
+	cleaner.Status.ConsecutiveFailures = 0
+	cleaner.Status.FailingSince = nil
+}
+
+// jobResourceRequests sums the resource requests of a Job's pod template.
+// Templates without containers or requests contribute nothing.
+func jobResourceRequests(job *batchv1.Job) corev1.ResourceList {
+	total := corev1.ResourceList{}
+	for _, container := range job.Spec.Template.Spec.Containers {
+		total = addResources(total, container.Resources.Requests)
+	}
+	return total
+}
+
+// keepJobsFailedWith keeps the Jobs whose JobFailed condition message
+// contains the given substring.
+func keepJobsFailedWith(jobs []batchv1.Job, substring string) []batchv1.Job {
+	kept := []batchv1.Job{}
+	for _, job := range jobs {
+		for _, condition := range job.Status.Conditions {
+			if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue &&
+				strings.Contains(condition.Message, substring) {
+				kept = append(kept, job)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+// sortByResourceRequests returns the Jobs ordered by their Pods' CPU
+// requests, then memory requests, highest first. Jobs with equal requests
+// keep their order. Peak usage would be a better measure, but it needs the
+// metrics API, which the controller does not read.
+func sortByResourceRequests(jobs []batchv1.Job) []batchv1.Job {
+	jobs = slices.Clone(jobs)
+	slices.SortStableFunc(jobs, func(a, b batchv1.Job) int {
+		ra, rb := jobResourceRequests(&a), jobResourceRequests(&b)
+		if c := rb.Cpu().Cmp(*ra.Cpu()); c != 0 {
+			return c
+		}
+		return rb.Memory().Cmp(*ra.Memory())
+	})
+	return jobs
+}
+
+// sumJobResourceRequests sums the pod template resource requests of all Jobs.
+func sumJobResourceRequests(jobs []batchv1.Job) corev1.ResourceList {
+	total := corev1.ResourceList{}
+	for i := range jobs {
+		total = addResources(total, jobResourceRequests(&jobs[i]))
+	}
+	return total
+}
+
+// addResources returns total with every quantity in add summed into it.
+func addResources(total, add corev1.ResourceList) corev1.ResourceList {
+	if total == nil {
+		total = corev1.ResourceList{}
+	}
+	for name, quantity := range add {
+		current := total[name]
+		current.Add(quantity)
+		total[name] = current
+	}
+	return total
+}
+
+// subtractResources returns total less every quantity in sub, leaving out
+// the resources that drop to zero or below.
+func subtractResources(total, sub corev1.ResourceList) corev1.ResourceList {
+	left := corev1.ResourceList{}
+	for name, quantity := range total {
+		current := quantity.DeepCopy()
+		current.Sub(sub[name])
+		if current.Sign() > 0 {
+			left[name] = current
+		}
+	}
+	return left
+}
+
+// defaultRequeueInterval is used when the spec has no usable run interval,
+// e.g. while it is invalid.
+const defaultRequeueInterval = 5 * time.Minute
+
+// requeueInterval returns how long to wait before the next reconcile.
+func requeueInterval(cleaner *lifecyclev1alpha1.CronExecutionCleaner) time.Duration {
+	if cleaner.Spec.RunInterval.Duration < time.Second {
+		return defaultRequeueInterval
+	}
+	return cleaner.Spec.RunInterval.Duration
+}
+
+// clampRunInterval raises the cleaner's run interval to floor and reports
+// whether it was below it. A floor of zero leaves it alone.
+func clampRunInterval(cleaner *lifecyclev1alpha1.CronExecutionCleaner, floor time.Duration) bool {
+	if floor <= 0 || requeueInterval(cleaner) >= floor {
+		return false
+	}
+	cleaner.Spec.RunInterval = metav1.Duration{Duration: floor}
+	return true
+}
+
+// defaultStarvationThreshold is the number of starved runs after which the
+// Starved condition is set when spec.starvationThreshold is unset.
+const defaultStarvationThreshold = 3
+
+// recordStarvation counts a run that hit the per-run deletion cap while
+// the owned Job count did not go down, and reports whether the cleaner has
+// been starved for long enough to tell the user.
+func recordStarvation(cleaner *lifecyclev1alpha1.CronExecutionCleaner, capped bool) bool {
+	if !capped || cleaner.Status.OwnedJobCountDelta < 0 {
+		cleaner.Status.StarvedRuns = 0
+		return false
+	}
+	cleaner.Status.StarvedRuns++
+
+	threshold := cleaner.Spec.StarvationThreshold
+	if threshold <= 0 {
+		threshold = defaultStarvationThreshold
+	}
+	return cleaner.Status.StarvedRuns >= threshold
+}
+
+// estimateDrainTime returns when the backlog Jobs held back by the
+// per-run deletion cap are expected to be deleted, at one capped run
+// per run interval, or nil when nothing is held back by the cap.
+func estimateDrainTime(cleaner *lifecyclev1alpha1.CronExecutionCleaner, backlog int, now time.Time) *metav1.Time {
+	limit := cleaner.Spec.MaxDeletionsPerRun
+	if backlog <= 0 || limit <= 0 {
+		return nil
+	}
+	runs := (backlog + limit - 1) / limit
+	drainAt := metav1.NewTime(now.Add(time.Duration(runs) * requeueInterval(cleaner)))
+	return &drainAt
+}
+
+// missingTargetBackoffFactor stretches the run interval while the target
+// CronJob is missing and no missingTargetRequeue is set.
+const missingTargetBackoffFactor = 4
+
+// missingTargetRequeue returns how long to wait before the next reconcile
+// while the target CronJob cannot be found.
+func missingTargetRequeue(cleaner *lifecyclev1alpha1.CronExecutionCleaner) time.Duration {
+	if d := cleaner.Spec.MissingTargetRequeue; d != nil && d.Duration >= time.Second {
+		return d.Duration
+	}
+	return missingTargetBackoffFactor * requeueInterval(cleaner)
+}
+
+// resetForRecreatedCleaner resets the lifetime counters when the status was
+// recorded for an earlier object of the same name, and records the current
+// UID. It reports whether the counters were reset. A status without a UID
+// predates the field and is kept.
+func resetForRecreatedCleaner(cleaner *lifecyclev1alpha1.CronExecutionCleaner) bool {
+	status := &cleaner.Status
+	recreated := status.ObservedUID != "" && status.ObservedUID != cleaner.UID
+	if recreated {
+		status.JobsDeleted = 0
+		status.PodsDeleted = 0
+		status.HourlyDeletions = nil
+		status.PendingForegroundDeletions = nil
+		status.PendingForegroundResources = nil
+		status.ServicesDeleted = 0
+		status.DeletionsSinceSpecChange = 0
+		status.ReclaimedResources = nil
+		status.LastRunTime = nil
+		// The new object has not been evaluated yet
+		status.LastEvaluatedTime = nil
+	}
+	status.ObservedUID = cleaner.UID
+	return recreated
+}
+
+// runMessage summarizes a run for status.message, e.g.
+// "Deleted 3 succeeded, 1 stuck; skipped 2 at 2026-01-01T00:00Z". Skipped Jobs
+// were selected for deletion but held back, e.g. by a deletion cap, warm-up or
+// dry run.
+func runMessage(deletedByReason map[string]int, quarantined, skipped int, now time.Time) string {
+	deleted := []string{}
+	for _, reason := range []string{"succeeded", "failed", "stuck", "abandoned", "leftover"} {
+		if n := deletedByReason[reason]; n > 0 {
+			deleted = append(deleted, fmt.Sprintf("%d %s", n, reason))
+		}
+	}
+
+	parts := []string{}
+	if len(deleted) > 0 {
+		parts = append(parts, "Deleted "+strings.Join(deleted, ", "))
+	}
+	if quarantined > 0 {
+		parts = append(parts, fmt.Sprintf("quarantined %d", quarantined))
+	}
+	if skipped > 0 {
+		parts = append(parts, fmt.Sprintf("skipped %d", skipped))
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "Nothing to delete")
+	}
+	message := strings.Join(parts, "; ")
+	message = strings.ToUpper(message[:1]) + message[1:]
+	return message + " at " + now.UTC().Format("2006-01-02T15:04Z07:00")
+}
+
+// nextEligibleTime returns the earliest time after now at which a Job kept by
+// the plan leaves its grace period or becomes stuck or abandoned, or nil if
+// none will. Stuck Jobs found through Pod conditions are not predicted.
+func nextEligibleTime(spec *lifecyclev1alpha1.CronExecutionCleanerSpec, plan DeletionPlan, now time.Time) *time.Time {
+	var next *time.Time
+	consider := func(t time.Time) {
+		if t.After(now) && (next == nil || t.Before(*next)) {
+			next = &t
+		}
+	}
+
+	for _, window := range []struct {
+		jobs   []batchv1.Job
+		period *metav1.Duration
+	}{
+		{plan.Succeeded, spec.Retain.SuccessfulGrace},
+		{plan.Failed, spec.Retain.FailedGrace},
+		{plan.Succeeded, spec.Retain.SuccessfulTTL},
+		{plan.Failed, spec.Retain.FailedTTL},
+	} {
+		if window.period == nil {
+			continue
+		}
+		for i := range window.jobs {
+			if finishedAt := jobFinishedAt(&window.jobs[i]); finishedAt != nil {
+				consider(finishedAt.Add(window.period.Duration))
+			}
+		}
+	}
+
+	if spec.CleanupStuck.Enabled && !spec.CleanupStuck.UsePodConditionAge {
+		for _, job := range plan.Active {
+			if job.Status.StartTime == nil {
+				continue
+			}
+			consider(job.Status.StartTime.Add(spec.CleanupStuck.StuckAfter.Duration))
+			if maxAge := spec.CleanupStuck.MaxAge; maxAge != nil {
+				consider(job.Status.StartTime.Add(maxAge.Duration))
+			}
+		}
+	}
+	return next
+}
+
+// messageWithoutTime strips the time runMessage appends.
+func messageWithoutTime(message string) string {
+	if i := strings.LastIndex(message, " at "); i >= 0 {
+		return message[:i]
+	}
+	return message
+}
+
+// evaluationDelay returns how long until the cleaner is next due for
+// evaluation. Zero means it is due now, either because the spec changed since
+// the last evaluation, because a full run interval has elapsed or because a
+// throttled run is due for a retry.
+func evaluationDelay(cleaner *lifecyclev1alpha1.CronExecutionCleaner, now time.Time) time.Duration {
+	if cleaner.Status.LastEvaluatedTime == nil ||
+		cleaner.Status.ObservedGeneration != cleaner.Generation {
+		return 0
+	}
+
+	next := cleaner.Status.LastEvaluatedTime.Add(cleaner.Spec.RunInterval.Duration)
+	if eligible := cleaner.Status.NextEligibleTime; eligible != nil && eligible.Time.Before(next) {
+		next = eligible.Time
+	}
+	if retry := cleaner.Status.RetryTime; retry != nil && retry.Time.Before(next) {
+		next = retry.Time
+	}
+	if !now.Before(next) {
+		return 0
+	}
+	return next.Sub(now)
+}
+
+// deletionBudget caps the number of Jobs deleted during a single run. A limit
+// of zero disables the cap.
+type deletionBudget struct {
+	limit int
+	used  int
+
+	// Jobs that may still be taken overall. Negative means no overall cap.
+	total int
+
+	// Whether a Job was held back by the per-run limit
+	capped bool
+}
+
+func newDeletionBudget(limit int) *deletionBudget {
+	return &deletionBudget{limit: limit, total: -1}
+}
+
+// take returns the jobs that still fit within the run's budget and consumes
+// the budget for them.
+func (b *deletionBudget) take(jobs []batchv1.Job) []batchv1.Job {
+	if b.limit <= 0 && b.total < 0 {
+		return jobs
+	}
+
+	allowed := []batchv1.Job{}
+	for _, job := range jobs {
+		if b.limit > 0 && b.used >= b.limit {
+			b.capped = true
+			continue
+		}
+		if b.total == 0 {
+			break
+		}
+		if b.total > 0 {
+			b.total--
+		}
+		b.used++
+		allowed = append(allowed, job)
+	}
+	return allowed
+}
+
+// lifetimeDeletionsLeft returns how many more Jobs the cleaner may delete
+// before reaching its lifetime deletion ceiling, or -1 without a ceiling.
+func lifetimeDeletionsLeft(cleaner *lifecyclev1alpha1.CronExecutionCleaner) int {
+	ceiling := cleaner.Spec.LifetimeDeletionCeiling
+	if ceiling <= 0 {
+		return -1
+	}
+	return max(ceiling-cleaner.Status.DeletionsSinceSpecChange, 0)
+}
+
+// deleteJobs deletes the given Jobs and returns those that were deleted.
+// When the API server throttles a deletion, the Job is retried after an
+// exponential backoff; if throttling persists, the pass stops early and
+// throttled is true.
+func (r *CronExecutionCleanerReconciler) deleteJobs(
+	ctx context.Context,
+	cleaner *lifecyclev1alpha1.CronExecutionCleaner,
+	jobs []batchv1.Job,
+	jobType string,
+) (deleted []batchv1.Job, throttled bool) {
+	logger := ctrl.LoggerFrom(ctx)
+	deleted = []batchv1.Job{}
+	backoff := throttleBackoff{}
+
+	if cleaner.Spec.ReadOnly {
+		if len(jobs) > 0 {
+			logger.Info("Read-only, not deleting jobs", "type", jobType, "jobs", jobNames(jobs))
+		}
+		return deleted, false
+	}
+
+	dryRun := dryRunFor(&cleaner.Spec, jobType)
+	if cleaner.Spec.DeterministicDeletionOrder {
+		jobs = sortByCreation(jobs)
+	}
+	policy := metav1.DeletePropagationBackground
+	if cleaner.Spec.DeletePropagation != "" {
+		policy = cleaner.Spec.DeletePropagation
+	}
+	for _, job := range jobs {
+		if dryRun {
+			logger.Info("Dry run, not deleting job", "type", jobType, "job", job.Name)
+			r.audit(ctx, cleaner, &job, jobType, true)
+			cleaner.Status.JobsWouldDelete++
+			continue
+		}
+		if !deletionTokenLeft(cleaner) {
+			logger.Info("Deletion rate limit reached, deleting the rest in a later run", "type", jobType, "job", job.Name)
+			return deleted, false
+		}
+		current, err := r.recheckJob(ctx, &job, jobType)
+		if err != nil {
+			logger.Error(err, "Failed to re-read job before deleting it", "type", jobType, "job", job.Name)
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		job = *current
+		if cleaner.Spec.MarkDeletedBy {
+			r.markDeletedBy(ctx, cleaner, &job, jobType)
+		}
+		// Only delete the Job exactly as it was just checked
+		opts := &client.DeleteOptions{
+			PropagationPolicy: &policy,
+			Preconditions: &metav1.Preconditions{
+				UID:             &job.UID,
+				ResourceVersion: &job.ResourceVersion,
+			},
+		}
+		release, err := r.NamespaceFence.acquire(ctx, job.Namespace)
+		if err != nil {
+			return deleted, true
+		}
+		logger.Info("Deleting job", "type", jobType, "job", job.Name)
+		err = r.Delete(ctx, &job, opts)
+		for apierrors.IsTooManyRequests(err) {
+			delay, ok := backoff.next(err)
+			if !ok {
+				release()
+				logger.Info("API server keeps throttling deletions, stopping early", "type", jobType, "job", job.Name)
+				return deleted, true
+			}
+			logger.Info("API server throttled deletion, backing off", "type", jobType, "job", job.Name, "delay", delay.String())
+			if err := r.pause(ctx, delay); err != nil {
+				release()
+				return deleted, true
+			}
+			err = r.Delete(ctx, &job, opts)
+		}
+		release()
+		backoff.reset()
+		if apierrors.IsNotFound(err) {
+			// Another cleaner sharing the target got there first. The Job is
+			// gone as intended, but it is counted by whoever deleted it.
+			logger.V(1).Info("Job already deleted", "type", jobType, "job", job.Name)
+			continue
+		}
+		if apierrors.IsConflict(err) {
+			logger.Info("Job changed while being deleted, retrying next run", "type", jobType, "job", job.Name)
+			jobsSkipped.WithLabelValues("changed").Inc()
+			continue
+		}
+		if err != nil {
+			logger.Error(err, "Failed to delete job", "type", jobType, "job", job.Name)
+			continue
+		}
+		spendDeletionToken(cleaner, r.now())
+		r.audit(ctx, cleaner, &job, jobType, false)
+		if policy == metav1.DeletePropagationForeground && !r.jobGone(ctx, &job) {
+			logger.Info("Job is terminating in the foreground, counting it once gone", "type", jobType, "job", job.Name)
+			cleaner.Status.PendingForegroundDeletions = append(cleaner.Status.PendingForegroundDeletions, job.Name)
+			cleaner.Status.PendingForegroundResources = addResources(
+				cleaner.Status.PendingForegroundResources,
+				jobResourceRequests(&job),
+			)
+			continue
+		}
+		deleted = append(deleted, job)
+	}
+	return deleted, false
+}
+
+// sortByCreation returns the Jobs ordered by creation time, oldest first,
+// with ties broken by name.
+func sortByCreation(jobs []batchv1.Job) []batchv1.Job {
+	jobs = slices.Clone(jobs)
+	slices.SortFunc(jobs, func(a, b batchv1.Job) int {
+		if c := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return jobs
+}
+
+// dryRunReasonSuffix marks the reason of events emitted during a dry run.
+const dryRunReasonSuffix = "DryRun"
+
+// eventReason returns the reason for an event about the cleanup run,
+// suffixed in dry run so that it cannot be mistaken for a real run's.
+func eventReason(spec *lifecyclev1alpha1.CronExecutionCleanerSpec, reason string) string {
+	if spec.DryRun {
+		return reason + dryRunReasonSuffix
+	}
+	return reason
+}
+
+// dryRunFor reports whether Jobs selected for the given reason are only
+// simulated: abandoned and stuck Jobs fall under dryRunStuck, succeeded and
+// failed ones under dryRunRetention.
+func dryRunFor(spec *lifecyclev1alpha1.CronExecutionCleanerSpec, reason string) bool {
+	if spec.DryRun {
+		return true
+	}
+	switch reason {
+	case "abandoned", "stuck":
+		return spec.DryRunStuck
+	case "succeeded", "failed":
+		return spec.DryRunRetention
+	}
+	return false
+}
+
+// markDeletedBy annotates the Job with the cleaner and reason about to delete
+// it. Failures are logged and do not hold up the deletion.
+func (r *CronExecutionCleanerReconciler) markDeletedBy(
+	ctx context.Context,
+	cleaner *lifecyclev1alpha1.CronExecutionCleaner,
+	job *batchv1.Job,
+	jobType string,
+) {
+	patch := client.MergeFrom(job.DeepCopy())
+	if job.Annotations == nil {
+		job.Annotations = map[string]string{}
+	}
+	job.Annotations[lifecyclev1alpha1.DeletedByAnnotation] = cleaner.Namespace + "/" + cleaner.Name
+	job.Annotations[lifecyclev1alpha1.DeletionReasonAnnotation] = jobType
+	job.Annotations[lifecyclev1alpha1.DeletionRequestedAtAnnotation] = r.now().UTC().Format(time.RFC3339)
+	if err := r.Patch(ctx, job, patch); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to annotate job before deleting it", "type", jobType, "job", job.Name)
+	}
+}
+
+// leftoverDeletionGrace is how long a Job marked for deletion by a cleaner
+// may still be around before the deletion is assumed to have been lost.
+const leftoverDeletionGrace = 5 * time.Minute
+
+// findLeftoverJobs returns the Jobs this cleaner marked for deletion more
+// than leftoverDeletionGrace ago that are still present and not terminating,
+// e.g. because the controller crashed between marking and deleting them.
+// Jobs marked without a time, by versions that did not record it, count as
+// past the grace.
+func findLeftoverJobs(
+	cleaner *lifecyclev1alpha1.CronExecutionCleaner,
+	jobs []batchv1.Job,
+	now time.Time,
+) []batchv1.Job {
+	owner := cleaner.Namespace + "/" + cleaner.Name
+	leftovers := []batchv1.Job{}
+	for _, job := range jobs {
+		if job.Annotations[lifecyclev1alpha1.DeletedByAnnotation] != owner || job.DeletionTimestamp != nil {
+			continue
+		}
+		if value, ok := job.Annotations[lifecyclev1alpha1.DeletionRequestedAtAnnotation]; ok {
+			requestedAt, err := time.Parse(time.RFC3339, value)
+			if err == nil && now.Sub(requestedAt) < leftoverDeletionGrace {
+				continue
+			}
+		}
+		leftovers = append(leftovers, job)
+	}
+	return leftovers
+}
+
+// recheckJob reads the Job again right before it is deleted, bypassing the
+// cache when possible, and returns it if it was still selected for the same
+// reason. It returns nil if the Job is gone or has changed state since it was
+// listed, e.g. a stuck Job that has since succeeded.
+func (r *CronExecutionCleanerReconciler) recheckJob(
+	ctx context.Context,
+	job *batchv1.Job,
+	jobType string,
+) (*batchv1.Job, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	var reader client.Reader = r.Client
+	if r.APIReader != nil {
+		reader = r.APIReader
+	}
+
+	var current batchv1.Job
+	if err := reader.Get(ctx, client.ObjectKeyFromObject(job), &current); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.V(1).Info("Job already deleted", "type", jobType, "job", job.Name)
+			return nil, nil
+		}
+		return nil, err
+	}
+	if current.UID != job.UID {
+		logger.Info("Job was recreated since it was listed, skipping", "type", jobType, "job", job.Name)
+		jobsSkipped.WithLabelValues("changed").Inc()
+		return nil, nil
+	}
+	if current.DeletionTimestamp != nil {
+		logger.Info("Job is already being deleted, skipping", "type", jobType, "job", job.Name)
+		jobsSkipped.WithLabelValues("terminating").Inc()
+		return nil, nil
+	}
+
+	active, succeeded, failed := classifyJobs([]batchv1.Job{current})
+	var stillSelected bool
+	switch jobType {
+	case "stuck", "abandoned":
+		stillSelected = len(active) == 1
+	case "succeeded":
+		stillSelected = len(succeeded) == 1
+	case "failed":
+		stillSelected = len(failed) == 1
+	default:
+		stillSelected = true
+	}
+	if !stillSelected {
+		logger.Info("Job changed state since it was listed, skipping", "type", jobType, "job", job.Name)
+		jobsSkipped.WithLabelValues("changed").Inc()
+		return nil, nil
+	}
+	return &current, nil
+}
+
+// jobGone reports whether the Job can no longer be found. Any other error
+// counts as the Job still being there.
+func (r *CronExecutionCleanerReconciler) jobGone(ctx context.Context, job *batchv1.Job) bool {
+	var current batchv1.Job
+	err := r.Get(ctx, client.ObjectKeyFromObject(job), &current)
+	return apierrors.IsNotFound(err)
+}
+
+// settleForegroundDeletions counts the pending foreground deletions whose
+// Jobs are no longer listed as deleted, the same way as direct deletions, and
+// returns the listed Jobs without the ones still terminating so they are not
+// deleted again.
+func settleForegroundDeletions(
+	cleaner *lifecyclev1alpha1.CronExecutionCleaner,
+	jobs []batchv1.Job,
+	now time.Time,
+) []batchv1.Job {
+	pending := cleaner.Status.PendingForegroundDeletions
+	if len(pending) == 0 {
+		return jobs
+	}
+
+	listed := map[string]bool{}
+	for _, job := range jobs {
+		listed[job.Name] = true
+	}
+	terminating := []string{}
+	for _, name := range pending {
+		if listed[name] {
+			terminating = append(terminating, name)
+		}
+	}
+
+	kept := []batchv1.Job{}
+	terminatingResources := corev1.ResourceList{}
+	for i := range jobs {
+		if slices.Contains(terminating, jobs[i].Name) {
+			terminatingResources = addResources(terminatingResources, jobResourceRequests(&jobs[i]))
+			continue
+		}
+		kept = append(kept, jobs[i])
+	}
+
+	if settled := len(pending) - len(terminating); settled > 0 {
+		runTime := metav1.NewTime(now)
+		cleaner.Status.LastRunTime = &runTime
+		recordDeletions(&cleaner.Status, settled, now)
+		cleaner.Status.ReclaimedResources = addResources(
+			cleaner.Status.ReclaimedResources,
+			subtractResources(cleaner.Status.PendingForegroundResources, terminatingResources),
+		)
+	}
+	cleaner.Status.PendingForegroundDeletions = nil
+	cleaner.Status.PendingForegroundResources = nil
+	if len(terminating) > 0 {
+		cleaner.Status.PendingForegroundDeletions = terminating
+		cleaner.Status.PendingForegroundResources = terminatingResources
+	}
+	return kept
+}
+
+// recordDeletions adds confirmed Job deletions to the lifetime counters.
+func recordDeletions(status *lifecyclev1alpha1.CronExecutionCleanerStatus, count int, now time.Time) {
+	if count == 0 {
+		return
+	}
+	status.JobsDeleted += count
+	if len(status.HourlyDeletions) != 24 {
+		status.HourlyDeletions = make([]int, 24)
+	}
+	status.HourlyDeletions[now.UTC().Hour()] += count
+	status.DeletionsSinceSpecChange += count
+	status.PodsDeleted += count // 1 pod per job in our setup
+}
+
+// audit hands a record of the Job's deletion to the audit sink, if any.
+func (r *CronExecutionCleanerReconciler) audit(
+	ctx context.Context,
+	cleaner *lifecyclev1alpha1.CronExecutionCleaner,
+	job *batchv1.Job,
+	reason string,
+	dryRun bool,
+) {
+	if r.AuditSink == nil {
+		return
+	}
+	record := AuditRecord{
+		Time:   r.now(),
+		Actor:  client.ObjectKeyFromObject(cleaner).String(),
+		Object: client.ObjectKeyFromObject(job).String(),
+		Reason: reason,
+		DryRun: dryRun,
+	}
+	if err := r.AuditSink.Record(ctx, record); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to record audit entry", "job", job.Name)
+	}
+}
+
+// deleteAssociatedServices deletes the Services labeled with the names of the
+// given Jobs and returns how many were deleted.
+func (r *CronExecutionCleanerReconciler) deleteAssociatedServices(
+	ctx context.Context,
+	jobs []batchv1.Job,
+) int {
+	logger := ctrl.LoggerFrom(ctx)
+	deleted := 0
+
+	for _, job := range jobs {
+		var serviceList corev1.ServiceList
+		err := r.List(ctx, &serviceList,
+			client.InNamespace(job.Namespace),
+			client.MatchingLabels{"job-name": job.Name},
+		)
+		if err != nil {
+			logger.Error(err, "Failed to list services for job", "job", job.Name)
+			continue
+		}
+		for _, service := range serviceList.Items {
+			logger.Info("Deleting service of deleted job", "job", job.Name, "service", service.Name)
+			if err := r.Delete(ctx, &service); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				logger.Error(err, "Failed to delete service", "job", job.Name, "service", service.Name)
+				continue
+			}
+			deleted++
+		}
+	}
+	return deleted
+}
+
+// handleStuckJobs deletes or quarantines stuck Jobs according to the action.
+// It returns the deleted Jobs, the number of quarantined Jobs, and whether
+// deletions were throttled.
+func (r *CronExecutionCleanerReconciler) handleStuckJobs(
+	ctx context.Context,
+	cleaner *lifecyclev1alpha1.CronExecutionCleaner,
+	jobs []batchv1.Job,
+	action lifecyclev1alpha1.StuckAction,
+	reason string,
+	budget *deletionBudget,
+) (deleted []batchv1.Job, quarantined int, throttled bool) {
+	if cleaner.Spec.ReadOnly {
+		if len(jobs) > 0 {
+			ctrl.LoggerFrom(ctx).Info("Read-only, not acting on jobs", "type", reason, "jobs", jobNames(jobs))
+		}
+		return nil, 0, false
+	}
+	if action != lifecyclev1alpha1.StuckActionQuarantine {
+		deleted, throttled = r.deleteJobs(ctx, cleaner, budget.take(jobs), reason)
+		return deleted, 0, throttled
+	}
+
+	if dryRunFor(&cleaner.Spec, reason) {
+		ctrl.LoggerFrom(ctx).Info("Dry run, not quarantining jobs", "type", reason, "jobs", jobNames(jobs))
+		return nil, 0, false
+	}
+	labeled := r.quarantineJobs(ctx, jobs, cleaner.Spec.CleanupStuck.QuarantineLabel)
+	if len(labeled) > 0 {
+		r.Recorder.Eventf(
+			cleaner,
+			corev1.EventTypeWarning,
+			"StuckJobsQuarantined",
+			"Quarantined %d %s Jobs: %s",
+			len(labeled),
+			reason,
+			strings.Join(jobNames(labeled), ", "),
+		)
+	}
+	return nil, len(labeled), false
+}
+
+// withoutJobs returns the Jobs that are not among the excluded ones.
+func withoutJobs(jobs, excluded []batchv1.Job) []batchv1.Job {
+	names := map[string]bool{}
+	for _, job := range excluded {
+		names[job.Name] = true
+	}
+	kept := []batchv1.Job{}
+	for _, job := range jobs {
+		if !names[job.Name] {
+			kept = append(kept, job)
+		}
+	}
+	return kept
+}
+
+// dropQuarantinedJobs removes Jobs that already carry the quarantine label.
+func dropQuarantinedJobs(jobs []batchv1.Job, label string) []batchv1.Job {
+	remaining := []batchv1.Job{}
+	for _, job := range jobs {
+		if job.Labels[label] == "true" {
+			continue
+		}
+		remaining = append(remaining, job)
+	}
+	return remaining
+}
+
+// quarantineJobs labels the given Jobs instead of deleting them and returns
+// the Jobs that were labeled.
+func (r *CronExecutionCleanerReconciler) quarantineJobs(
+	ctx context.Context,
+	jobs []batchv1.Job,
+	label string,
+) []batchv1.Job {
+	logger := ctrl.LoggerFrom(ctx)
+	quarantined := []batchv1.Job{}
+
+	for _, job := range jobs {
+		logger.Info("Quarantining stuck job", "job", job.Name, "label", label)
+		patch := client.MergeFrom(job.DeepCopy())
+		if job.Labels == nil {
+			job.Labels = map[string]string{}
+		}
+		job.Labels[label] = "true"
+		if err := r.Patch(ctx, &job, patch); err != nil {
+			logger.Error(err, "Failed to quarantine job", "job", job.Name)
+			continue
+		}
+		quarantined = append(quarantined, job)
+	}
+	return quarantined
+}
+
+// adoptTTLJobs removes ttlSecondsAfterFinished from the given Jobs so the
+// TTL controller no longer deletes them behind the cleaner's back.
+func (r *CronExecutionCleanerReconciler) adoptTTLJobs(
+	ctx context.Context,
+	jobs []batchv1.Job,
+) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	for _, job := range jobs {
+		if job.Spec.TTLSecondsAfterFinished == nil {
+			continue
+		}
+		logger.Info("Adopting job with TTL", "job", job.Name, "ttlSecondsAfterFinished", *job.Spec.TTLSecondsAfterFinished)
+		patch := client.MergeFrom(job.DeepCopy())
+		job.Spec.TTLSecondsAfterFinished = nil
+		if err := r.Patch(ctx, &job, patch); err != nil {
+			logger.Error(err, "Failed to remove TTL from job", "job", job.Name)
+		}
+	}
+}
+
+// listJobPods returns the Pods created for the given Job.
+func (r *CronExecutionCleanerReconciler) listJobPods(
+	ctx context.Context,
+	job *batchv1.Job,
+) ([]corev1.Pod, error) {
+	var podList corev1.PodList
+
+	err := r.List(ctx, &podList,
+		client.InNamespace(job.Namespace),
+		client.MatchingLabels{"job-name": job.Name},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return podList.Items, nil
+}
+
+// getTargetCronJobs returns the CronJobs the cleaner targets by name. CronJobs
+// that do not exist are left out.
+func (r *CronExecutionCleanerReconciler) getTargetCronJobs(
+	ctx context.Context,
+	cleaner *lifecyclev1alpha1.CronExecutionCleaner,
+) (map[string]*batchv1.CronJob, error) {
+	cronJobs := map[string]*batchv1.CronJob{}
+	for _, name := range targetCronJobNames(&cleaner.Spec) {
+		var cronJob batchv1.CronJob
+		key := client.ObjectKey{Namespace: cleaner.Spec.Namespace, Name: name}
+		if err := r.Get(ctx, key, &cronJob); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return cronJobs, err
+		}
+		cronJobs[name] = &cronJob
+	}
+	return cronJobs, nil
+}
+
+// filterStalledJobs drops Jobs that still have a Pod starting up. Jobs whose
+// Pods cannot be listed are dropped as well, so that a lookup failure never
+// leads to a deletion.
+func (r *CronExecutionCleanerReconciler) filterStalledJobs(
+	ctx context.Context,
+	jobs []batchv1.Job,
+) []batchv1.Job {
+	logger := ctrl.LoggerFrom(ctx)
+	stalled := []batchv1.Job{}
+
+	for _, job := range jobs {
+		pods, err := r.listJobPods(ctx, &job)
+		if err != nil {
+			logger.Error(err, "Failed to list pods for job", "job", job.Name)
+			continue
+		}
+
+		starting := false
+		for i := range pods {
+			if podStillStarting(&pods[i]) {
+				starting = true
+				break
+			}
+		}
+		if starting {
+			logger.Info("Job pod is still starting, not treating as stuck", "job", job.Name)
+			continue
+		}
+		stalled = append(stalled, job)
+	}
+	return stalled
+}
+
+// dropJobsWithinStartupProbe drops Jobs that have a Pod still within its
+// startup probe budget. Jobs whose Pods cannot be listed are dropped as well.
+func (r *CronExecutionCleanerReconciler) dropJobsWithinStartupProbe(
+	ctx context.Context,
+	jobs []batchv1.Job,
+	now time.Time,
+) []batchv1.Job {
+	logger := ctrl.LoggerFrom(ctx)
+	kept := []batchv1.Job{}
+
+	for _, job := range jobs {
+		pods, err := r.listJobPods(ctx, &job)
+		if err != nil {
+			logger.Error(err, "Failed to list pods for job", "job", job.Name)
+			continue
+		}
+
+		probing := false
+		for i := range pods {
+			if withinStartupProbe(&pods[i], now) {
+				probing = true
+				break
+			}
+		}
+		if probing {
+			logger.Info("Job pod is within its startup probe budget, not treating as stuck", "job", job.Name)
+			continue
+		}
+		kept = append(kept, job)
+	}
+	return kept
+}
+
+// findUnschedulableJobs returns the Jobs with a Pod that has been
+// unschedulable for longer than stuckAfter. Jobs whose Pods cannot be listed
+// are left out.
+func (r *CronExecutionCleanerReconciler) findUnschedulableJobs(
+	ctx context.Context,
+	jobs []batchv1.Job,
+	stuckAfter time.Duration,
+	now time.Time,
+) []batchv1.Job {
+	logger := ctrl.LoggerFrom(ctx)
+	unschedulable := []batchv1.Job{}
+
+	for _, job := range jobs {
+		pods, err := r.listJobPods(ctx, &job)
+		if err != nil {
+			logger.Error(err, "Failed to list pods for job", "job", job.Name)
+			continue
+		}
+		for i := range pods {
+			if podUnschedulableFor(&pods[i], stuckAfter, now) {
+				logger.Info("Job pod is unschedulable, treating as stuck", "job", job.Name, "pod", pods[i].Name)
+				unschedulable = append(unschedulable, job)
+				break
+			}
+		}
+	}
+	return unschedulable
+}
+
+// filterJobsWithStalePodConditions keeps the Jobs whose Pods have not had a
+// condition transition or probe within stuckAfter. Jobs whose Pods cannot be
+// listed are dropped.
+func (r *CronExecutionCleanerReconciler) filterJobsWithStalePodConditions(
+	ctx context.Context,
+	jobs []batchv1.Job,
+	stuckAfter time.Duration,
+	now time.Time,
+) []batchv1.Job {
+	logger := ctrl.LoggerFrom(ctx)
+	stale := []batchv1.Job{}
+
+	for _, job := range jobs {
+		pods, err := r.listJobPods(ctx, &job)
+		if err != nil {
+			logger.Error(err, "Failed to list pods for job", "job", job.Name)
+			continue
+		}
+		if podConditionsStale(pods, stuckAfter, now) {
+			stale = append(stale, job)
+		}
+	}
+	return stale
+}
+
+// pvcInUse reports whether a PersistentVolumeClaim is still bound and not on
+// its way out.
+func pvcInUse(pvc *corev1.PersistentVolumeClaim) bool {
+	return pvc.DeletionTimestamp == nil && pvc.Status.Phase == corev1.ClaimBound
+}
+
+// dropJobsHoldingPVCs drops Jobs whose Pods reference a PersistentVolumeClaim
+// that is still in use. Jobs whose Pods or claims cannot be read are dropped
+// as well.
+func (r *CronExecutionCleanerReconciler) dropJobsHoldingPVCs(
+	ctx context.Context,
+	jobs []batchv1.Job,
+) []batchv1.Job {
+	logger := ctrl.LoggerFrom(ctx)
+	kept := []batchv1.Job{}
+	inUse := map[client.ObjectKey]bool{}
+
+	claimInUse := func(key client.ObjectKey) (bool, error) {
+		if used, ok := inUse[key]; ok {
+			return used, nil
+		}
+		var pvc corev1.PersistentVolumeClaim
+		if err := r.Get(ctx, key, &pvc); err != nil {
+			if apierrors.IsNotFound(err) {
+				inUse[key] = false
+				return false, nil
+			}
+			return false, err
+		}
+		inUse[key] = pvcInUse(&pvc)
+		return inUse[key], nil
+	}
+
+	for _, job := range jobs {
+		pods, err := r.listJobPods(ctx, &job)
+		if err != nil {
+			logger.Error(err, "Failed to list pods for job", "job", job.Name)
+			continue
+		}
+
+		holding := false
+		for _, pod := range pods {
+			for _, volume := range pod.Spec.Volumes {
+				if volume.PersistentVolumeClaim == nil {
+					continue
+				}
+				key := client.ObjectKey{Namespace: pod.Namespace, Name: volume.PersistentVolumeClaim.ClaimName}
+				used, err := claimInUse(key)
+				if err != nil {
+					logger.Error(err, "Failed to get PersistentVolumeClaim", "job", job.Name, "claim", key.Name)
+					used = true
+				}
+				if used {
+					holding = true
+					break
+				}
+			}
+			if holding {
+				break
+			}
+		}
+		if holding {
+			logger.Info("Job pod still references a PersistentVolumeClaim in use, skipping", "job", job.Name)
+			continue
+		}
+		kept = append(kept, job)
+	}
+	return kept
+}
+
+// dropJobsAwaitingVolumeDetach drops Jobs with a Pod whose PersistentVolume
+// is still attached to the Pod's node according to its VolumeAttachment.
+// Pods do not report detachment themselves. Jobs whose Pods, claims or
+// VolumeAttachments cannot be read are dropped as well.
+func (r *CronExecutionCleanerReconciler) dropJobsAwaitingVolumeDetach(
+	ctx context.Context,
+	jobs []batchv1.Job,
+) []batchv1.Job {
+	logger := ctrl.LoggerFrom(ctx)
+	if len(jobs) == 0 {
+		return jobs
+	}
+
+	type attachment struct{ volume, node string }
+	var attachments storagev1.VolumeAttachmentList
+	if err := r.List(ctx, &attachments); err != nil {
+		logger.Error(err, "Failed to list VolumeAttachments, deferring jobs", "jobs", jobNames(jobs))
+		return []batchv1.Job{}
+	}
+	attached := map[attachment]bool{}
+	for _, va := range attachments.Items {
+		if va.Spec.Source.PersistentVolumeName != nil && va.Status.Attached {
+			attached[attachment{*va.Spec.Source.PersistentVolumeName, va.Spec.NodeName}] = true
+		}
+	}
+
+	kept := []batchv1.Job{}
+	for _, job := range jobs {
+		pods, err := r.listJobPods(ctx, &job)
+		if err != nil {
+			logger.Error(err, "Failed to list pods for job", "job", job.Name)
+			continue
+		}
+
+		pending := false
+		for _, pod := range pods {
+			for _, volume := range pod.Spec.Volumes {
+				if pending || volume.PersistentVolumeClaim == nil || pod.Spec.NodeName == "" {
+					continue
+				}
+				var pvc corev1.PersistentVolumeClaim
+				key := client.ObjectKey{Namespace: pod.Namespace, Name: volume.PersistentVolumeClaim.ClaimName}
+				if err := r.Get(ctx, key, &pvc); err != nil {
+					if apierrors.IsNotFound(err) {
+						continue
+					}
+					logger.Error(err, "Failed to get PersistentVolumeClaim", "job", job.Name, "claim", key.Name)
+					pending = true
+					continue
+				}
+				pending = attached[attachment{pvc.Spec.VolumeName, pod.Spec.NodeName}]
+			}
+		}
+		if pending {
+			logger.Info("Job pod volume is still attached to its node, deferring", "job", job.Name)
+			continue
+		}
+		kept = append(kept, job)
+	}
+	return kept
+}
+
+// logsShipped reports whether every Pod carries the annotation set to "true".
+func logsShipped(pods []corev1.Pod, annotation string) bool {
+	for _, pod := range pods {
+		if pod.Annotations[annotation] != "true" {
+			return false
+		}
+	}
+	return true
+}
+
+// podExitedWith reports whether any container of the Pod, init containers
+// included, terminated with one of the exit codes, now or before its last
+// restart.
+func podExitedWith(pod *corev1.Pod, codes []int32) bool {
+	statuses := append(slices.Clone(pod.Status.InitContainerStatuses), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		for _, state := range []corev1.ContainerState{status.State, status.LastTerminationState} {
+			if state.Terminated != nil && slices.Contains(codes, state.Terminated.ExitCode) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// dropJobsWithKeptExitCodes drops Jobs with a Pod container that terminated
+// with one of the exit codes. Jobs whose Pods cannot be listed are dropped as
+// well.
+func (r *CronExecutionCleanerReconciler) dropJobsWithKeptExitCodes(
+	ctx context.Context,
+	jobs []batchv1.Job,
+	codes []int32,
+) []batchv1.Job {
+	logger := ctrl.LoggerFrom(ctx)
+	kept := []batchv1.Job{}
+
+	for _, job := range jobs {
+		pods, err := r.listJobPods(ctx, &job)
+		if err != nil {
+			logger.Error(err, "Failed to list pods for job", "job", job.Name)
+			continue
+		}
+		if slices.ContainsFunc(pods, func(pod corev1.Pod) bool { return podExitedWith(&pod, codes) }) {
+			logger.Info("Job failed with a kept exit code, keeping it", "job", job.Name)
+			continue
+		}
+		kept = append(kept, job)
+	}
+	return kept
+}
+
+// dropJobsWithUnshippedLogs drops Jobs whose Pods have not yet been marked
+// with the logs-shipped annotation. Jobs whose Pods cannot be listed are
+// dropped as well.
+func (r *CronExecutionCleanerReconciler) dropJobsWithUnshippedLogs(
+	ctx context.Context,
+	jobs []batchv1.Job,
+	annotation string,
+) []batchv1.Job {
+	logger := ctrl.LoggerFrom(ctx)
+	kept := []batchv1.Job{}
+
+	for _, job := range jobs {
+		pods, err := r.listJobPods(ctx, &job)
+		if err != nil {
+			logger.Error(err, "Failed to list pods for job", "job", job.Name)
+			continue
+		}
+		if !logsShipped(pods, annotation) {
+			logger.Info("Job pod logs not shipped yet, deferring", "job", job.Name, "annotation", annotation)
+			continue
+		}
+		kept = append(kept, job)
+	}
+	return kept
+}
+
+// recoverPanic records a panic raised while reconciling the cleaner and turns
+// it into an error so that the request is retried with backoff.
+func (r *CronExecutionCleanerReconciler) recoverPanic(
+	ctx context.Context,
+	req ctrl.Request,
+	recovered interface{},
+) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	err := fmt.Errorf("panic while reconciling %s: %v", req.NamespacedName, recovered)
+	logger.Error(err, "Recovered from panic", "name", req.NamespacedName, "stack", string(debug.Stack()))
+
+	var cleaner lifecyclev1alpha1.CronExecutionCleaner
+	if getErr := r.Get(ctx, req.NamespacedName, &cleaner); getErr != nil {
+		logger.Error(getErr, "unable to fetch CronExecutionCleaner after panic")
+		return ctrl.Result{}, err
+	}
+	observed := cleaner.Status.DeepCopy()
+
+	setCondition(
+		&cleaner,
+		"ReconcilePanic",
+		metav1.ConditionTrue,
+		"Panic",
+		fmt.Sprint(recovered),
+	)
+	cleaner.Status.Phase = lifecyclev1alpha1.PhaseError
+	r.updateStatus(ctx, &cleaner, observed)
+
+	return ctrl.Result{}, err
+}
+
+// statusUnchanged reports whether writing the proposed status would change
+// anything besides the evaluation time, including the one in the message.
+func statusUnchanged(observed, proposed *lifecyclev1alpha1.CronExecutionCleanerStatus) bool {
+	compared := proposed.DeepCopy()
+	compared.LastEvaluatedTime = observed.LastEvaluatedTime
+	if messageWithoutTime(compared.Message) == messageWithoutTime(observed.Message) {
+		compared.Message = observed.Message
+	}
+	return equality.Semantic.DeepEqual(observed, compared)
+}
+
+// runSummary is the compact summary of a pass written to the last-run
+// annotation.
+type runSummary struct {
+	Time    metav1.Time `json:"time"`
+	Deleted int         `json:"deleted"`
+	Skipped int         `json:"skipped"`
+}
+
+// cleanupSummary is the summary of a pass written to the last-cleanup
+// annotation of the target CronJob.
+type cleanupSummary struct {
+	Time     metav1.Time    `json:"time"`
+	Cleaner  string         `json:"cleaner"`
+	Deleted  int            `json:"deleted"`
+	ByReason map[string]int `json:"byReason"`
+	Skipped  int            `json:"skipped"`
+}
+
+// annotateTargetCronJob patches the last-cleanup annotation on the target
+// CronJob. A missing CronJob is not an error.
+func (r *CronExecutionCleanerReconciler) annotateTargetCronJob(
+	ctx context.Context,
+	cronJob *batchv1.CronJob,
+	summary cleanupSummary,
+) {
+	logger := ctrl.LoggerFrom(ctx)
+	if cronJob == nil {
+		logger.V(1).Info("Target CronJob not found, not annotating it")
+		return
+	}
+
+	value, err := json.Marshal(summary)
+	if err != nil {
+		logger.Error(err, "Failed to encode last-cleanup summary")
+		return
+	}
+	annotated := cronJob.DeepCopy()
+	patch := client.MergeFrom(cronJob)
+	if annotated.Annotations == nil {
+		annotated.Annotations = map[string]string{}
+	}
+	annotated.Annotations[lifecyclev1alpha1.LastCleanupAnnotation] = string(value)
+	if err := r.Patch(ctx, annotated, patch); client.IgnoreNotFound(err) != nil {
+		logger.Error(err, "Failed to write last-cleanup annotation to the target CronJob", "cronJob", cronJob.Name)
+	}
+}
+
+// writeSummaryAnnotation patches the last-run annotation on the cleaner.
+// The patch is made on a copy, so the cleaner keeps its effective spec and
+// status.
+func (r *CronExecutionCleanerReconciler) writeSummaryAnnotation(
+	ctx context.Context,
+	cleaner *lifecyclev1alpha1.CronExecutionCleaner,
+	summary runSummary,
+) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	value, err := json.Marshal(summary)
+	if err != nil {
+		logger.Error(err, "Failed to encode last-run summary")
+		return
+	}
+	annotated := &lifecyclev1alpha1.CronExecutionCleaner{}
+	cleaner.ObjectMeta.DeepCopyInto(&annotated.ObjectMeta)
+	patch := client.MergeFrom(annotated.DeepCopy())
+	if annotated.Annotations == nil {
+		annotated.Annotations = map[string]string{}
+	}
+	annotated.Annotations[lifecyclev1alpha1.LastRunAnnotation] = string(value)
+	if err := r.Patch(ctx, annotated, patch); err != nil {
+		logger.Error(err, "Failed to write last-run annotation")
+	}
+}
+
+// statusListBudget is the number of bytes the name lists in status may take
+// up together, well below etcd's object size limit.
+const statusListBudget = 128 * 1024
+
+// defaultMaxConditions is the number of condition types kept in status when
+// spec.maxConditions is unset.
+const defaultMaxConditions = 8
+
+// capConditions drops the conditions that transitioned least recently until
+// at most limit remain. The Ready condition is never dropped. It returns the
+// number of dropped conditions.
+func capConditions(status *lifecyclev1alpha1.CronExecutionCleanerStatus, limit int) int {
+	if limit <= 0 {
+		limit = defaultMaxConditions
+	}
+	excess := len(status.Conditions) - limit
+	if excess <= 0 {
+		return 0
+	}
+
+	candidates := []metav1.Condition{}
+	for _, condition := range status.Conditions {
+		if condition.Type != "Ready" {
+			candidates = append(candidates, condition)
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b metav1.Condition) int {
+		if c := a.LastTransitionTime.Compare(b.LastTransitionTime.Time); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Type, b.Type)
+	})
+
+	dropped := 0
+	for _, condition := range candidates[:min(excess, len(candidates))] {
+		meta.RemoveStatusCondition(&status.Conditions, condition.Type)
+		dropped++
+	}
+	return dropped
+}
+
+// listEntryOverhead approximates the bytes a list entry adds on top of the
+// name itself: quotes and a separator.
+const listEntryOverhead = 3
+
+// capStatusLists drops the oldest entries of the name lists in status until
+// they fit into statusListBudget together. Stuck Job names are only
+// informational and are dropped first. It returns the number of dropped
+// entries.
+func capStatusLists(status *lifecyclev1alpha1.CronExecutionCleanerStatus) int {
+	lists := []*[]string{&status.StuckJobNames, &status.PendingForegroundDeletions}
+
+	size := 0
+	for _, list := range lists {
+		for _, name := range *list {
+			size += len(name) + listEntryOverhead
+		}
+	}
+
+	dropped := 0
+	for _, list := range lists {
+		for size > statusListBudget && len(*list) > 0 {
+			size -= len((*list)[0]) + listEntryOverhead
+			*list = (*list)[1:]
+			dropped++
+		}
+	}
+	return dropped
+}
+
+// writeStatus writes the cleaner's status through the status subresource,
+// falling back to a plain update when the CRD was installed without one. The
+// API server answers NotFound for a missing subresource, which is told apart
+// from a deleted cleaner by reading the cleaner back.
+func (r *CronExecutionCleanerReconciler) writeStatus(
+	ctx context.Context,
+	cleaner *lifecyclev1alpha1.CronExecutionCleaner,
+) error {
+	var latest lifecyclev1alpha1.CronExecutionCleaner
+	if !r.statusSubresourceMissing.Load() {
+		// The update reads the stored object back into cleaner; keep acting
+		// on the effective spec for the rest of the run
+		spec := cleaner.Spec
+		err := r.Status().Update(ctx, cleaner)
+		cleaner.Spec = spec
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		if getErr := r.Get(ctx, client.ObjectKeyFromObject(cleaner), &latest); getErr != nil {
+			return err
+		}
+		if r.statusSubresourceMissing.CompareAndSwap(false, true) {
+			ctrl.LoggerFrom(ctx).Info(
+				"CronExecutionCleaner CRD has no status subresource, writing status with plain updates from now on",
+			)
+		}
+	} else if err := r.Get(ctx, client.ObjectKeyFromObject(cleaner), &latest); err != nil {
+		return err
+	}
+
+	// A plain update writes the whole object, so start from the stored one
+	// rather than the in-memory copy whose spec has been defaulted
+	latest.Status = *cleaner.Status.DeepCopy()
+	return r.Update(ctx, &latest)
+}
+
+// updateStatus writes the cleaner status on a best-effort basis. Writes that
+// would not change the observed status are skipped. Failures are logged,
+// counted and surfaced as an event so that a broken status subresource never
+// blocks deletions.
+func (r *CronExecutionCleanerReconciler) updateStatus(
+	ctx context.Context,
+	cleaner *lifecyclev1alpha1.CronExecutionCleaner,
+	observed *lifecyclev1alpha1.CronExecutionCleanerStatus,
+) {
+	logger := ctrl.LoggerFrom(ctx)
+	if dropped := capStatusLists(&cleaner.Status); dropped > 0 {
+		logger.Info("Status lists exceed their size budget, dropped oldest entries", "dropped", dropped)
+	}
+	if dropped := capConditions(&cleaner.Status, cleaner.Spec.MaxConditions); dropped > 0 {
+		logger.Info("Too many condition types, dropped the least recently transitioned", "dropped", dropped)
+	}
+	r.ObjectMetrics.record(cleaner)
+
+	if observed != nil && statusUnchanged(observed, &cleaner.Status) {
+		logger.V(1).Info("Status unchanged, skipping update")
+		return
+	}
+
+	if err := r.writeStatus(ctx, cleaner); err != nil {
+		logger.Error(err, "Failed to update CronExecutionCleaner status")
+		statusUpdateFailures.With(cleanerLabels(cleaner)).Inc()
+		r.Recorder.Event(
+			cleaner,
+			corev1.EventTypeWarning,
+			"StatusUpdateFailed",
+			err.Error(),
+		)
 	}
-	return deletedCount
 }
@@ -1,11 +1,26 @@
 package controller
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	lifecyclev1alpha1 "github.com/bhatpriyanka8/cron-execution-cleaner/api/v1alpha1"
 )
 
 func TestClassifyJobs(t *testing.T) {
@@ -22,12 +37,17 @@ func TestClassifyJobs(t *testing.T) {
 			ObjectMeta: metav1.ObjectMeta{Name: "failed-job"},
 			Status:     batchv1.JobStatus{Failed: 1},
 		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "partial-job"},
+			Spec:       batchv1.JobSpec{Completions: ptr.To[int32](3)},
+			Status:     batchv1.JobStatus{Succeeded: 2},
+		},
 	}
 
 	active, succeeded, failed := classifyJobs(jobs)
 
-	if len(active) != 1 || active[0].Name != "active-job" {
-		t.Fatalf("expected 1 active job, got %d", len(active))
+	if len(active) != 2 || active[0].Name != "active-job" || active[1].Name != "partial-job" {
+		t.Fatalf("expected 2 active jobs, got %d", len(active))
 	}
 	if len(succeeded) != 1 || succeeded[0].Name != "succeeded-job" {
 		t.Fatalf("expected 1 succeeded job, got %d", len(succeeded))
@@ -57,12 +77,80 @@ func TestFilterJobsByOwner(t *testing.T) {
 		},
 	}
 
-	filtered := filterJobsByOwner(jobs, "my-cronjob")
+	owned, _ := filterJobsByOwner(jobs, map[string]types.UID{"my-cronjob": ""}, []string{"CronJob"}, false)
+	filtered := owned["my-cronjob"]
 
 	if len(filtered) != 1 || filtered[0].Name != "job-1" {
 		t.Fatalf("expected 1 filtered job, got %d", len(filtered))
 	}
 }
+
+func TestFilterJobsByOwnerRequireController(t *testing.T) {
+	isController := true
+	jobs := []batchv1.Job{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "controlled-job",
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "CronJob", Name: "my-cronjob", Controller: &isController},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "adopted-job",
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "CronJob", Name: "my-cronjob"},
+					{Kind: "Workflow", Name: "other-controller", Controller: &isController},
+				},
+			},
+		},
+	}
+
+	owners := map[string]types.UID{"my-cronjob": ""}
+	if owned, _ := filterJobsByOwner(jobs, owners, []string{"CronJob"}, false); len(owned["my-cronjob"]) != 2 {
+		t.Fatalf("expected 2 filtered jobs without controller requirement, got %d", len(owned["my-cronjob"]))
+	}
+
+	owned, _ := filterJobsByOwner(jobs, owners, []string{"CronJob"}, true)
+	filtered := owned["my-cronjob"]
+
+	if len(filtered) != 1 || filtered[0].Name != "controlled-job" {
+		t.Fatalf("expected only controlled-job, got %d jobs", len(filtered))
+	}
+}
+
+func TestFilterJobsByOwnerForeignUID(t *testing.T) {
+	jobs := []batchv1.Job{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "owned-job",
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "CronJob", Name: "my-cronjob", UID: "cronjob-uid"},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foreign-job",
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "CronJob", Name: "my-cronjob", UID: "other-cronjob-uid"},
+				},
+			},
+		},
+	}
+
+	ownedByName, foreign := filterJobsByOwner(jobs, map[string]types.UID{"my-cronjob": "cronjob-uid"}, []string{"CronJob"}, false)
+	owned := ownedByName["my-cronjob"]
+
+	if len(owned) != 1 || owned[0].Name != "owned-job" {
+		t.Fatalf("expected only owned-job to be owned, got %v", jobNames(owned))
+	}
+	if len(foreign) != 1 || foreign[0].Name != "foreign-job" {
+		t.Fatalf("expected foreign-job to be reported as foreign, got %v", jobNames(foreign))
+	}
+}
+
 func TestDetectStuckJobs(t *testing.T) {
 	now := time.Now()
 
@@ -140,6 +228,34 @@ func TestExcessJobsWithStartTime(t *testing.T) {
 	}
 }
 
+func TestExcessJobsLeavesInputOrderUnchanged(t *testing.T) {
+	now := time.Now()
+	jobs := []batchv1.Job{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "job-1"},
+			Status: batchv1.JobStatus{
+				StartTime: &metav1.Time{Time: now.Add(-3 * time.Hour)},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "job-2"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "job-3"},
+			Status: batchv1.JobStatus{
+				StartTime: &metav1.Time{Time: now},
+			},
+		},
+	}
+
+	excessJobs(jobs, 1)
+
+	want := []string{"job-1", "job-2", "job-3"}
+	if got := jobNames(jobs); !slices.Equal(got, want) {
+		t.Fatalf("expected input order %v to be preserved, got %v", want, got)
+	}
+}
+
 func TestExcessJobsNone(t *testing.T) {
 	jobs := []batchv1.Job{
 		{ObjectMeta: metav1.ObjectMeta{Name: "job-1"}},
@@ -152,3 +268,444 @@ func TestExcessJobsNone(t *testing.T) {
 		t.Fatalf("expected no excess jobs, got %d", len(excess))
 	}
 }
+
+func TestDeletionBudget(t *testing.T) {
+	jobs := []batchv1.Job{
+		{ObjectMeta: metav1.ObjectMeta{Name: "job-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "job-2"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "job-3"}},
+	}
+
+	budget := newDeletionBudget(2)
+	allowed := budget.take(jobs)
+
+	if len(allowed) != 2 {
+		t.Fatalf("expected 2 deletions, got %d", len(allowed))
+	}
+	if !budget.capped {
+		t.Fatalf("expected the budget to report the held back job")
+	}
+
+	// The budget is shared across calls within the same run
+	more := budget.take([]batchv1.Job{{ObjectMeta: metav1.ObjectMeta{Name: "job-4"}}})
+	if len(more) != 0 {
+		t.Fatalf("expected the budget to be exhausted, got %d", len(more))
+	}
+}
+
+func TestDeletionBudgetUnlimited(t *testing.T) {
+	jobs := []batchv1.Job{
+		{ObjectMeta: metav1.ObjectMeta{Name: "job-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "job-2"}},
+	}
+
+	allowed := newDeletionBudget(0).take(jobs)
+
+	if len(allowed) != 2 {
+		t.Fatalf("expected no cap, got %d", len(allowed))
+	}
+}
+
+func TestEffectiveSpec(t *testing.T) {
+	cleaner := &lifecyclev1alpha1.CronExecutionCleaner{
+		ObjectMeta: metav1.ObjectMeta{Name: "cleaner", Namespace: "team-a"},
+		Spec: lifecyclev1alpha1.CronExecutionCleanerSpec{
+			Namespace:   "team-b",
+			CronJobName: "my-cronjob",
+			Retain: lifecyclev1alpha1.RetentionPolicy{
+				SuccessfulJobs: 3,
+				FailedJobs:     1,
+			},
+			RunInterval: metav1.Duration{Duration: time.Minute},
+		},
+	}
+
+	spec := EffectiveSpec(cleaner)
+
+	if spec.Namespace != "team-b" || spec.CronJobName != "my-cronjob" || spec.Retain.SuccessfulJobs != 3 ||
+		spec.RunInterval.Duration != time.Minute {
+		t.Fatalf("expected explicit fields to be preserved, got %+v", spec)
+	}
+	if cleaner.Spec.OwnerKinds != nil {
+		t.Fatalf("expected cleaner spec to be left untouched")
+	}
+
+	if len(spec.OwnerKinds) != 1 || spec.OwnerKinds[0] != "CronJob" {
+		t.Fatalf("expected owner kinds to default to CronJob, got %v", spec.OwnerKinds)
+	}
+}
+
+func TestFailedRetentionElevated(t *testing.T) {
+	retain := lifecyclev1alpha1.RetentionPolicy{
+		FailedJobs:            2,
+		ElevateThresholdRatio: "0.5",
+		ElevateFactor:         3,
+	}
+
+	// 3 failed out of 4 completed jobs
+	ratio := failureRatio(1, 3)
+	if ratio != 0.75 {
+		t.Fatalf("expected failure ratio 0.75, got %v", ratio)
+	}
+	if got := failedRetention(retain, ratio); got != 6 {
+		t.Fatalf("expected elevated retention of 6, got %d", got)
+	}
+
+	// 1 failed out of 4 completed jobs stays below the threshold
+	if got := failedRetention(retain, failureRatio(3, 1)); got != 2 {
+		t.Fatalf("expected base retention of 2, got %d", got)
+	}
+}
+
+func TestFailedRetentionWithoutThreshold(t *testing.T) {
+	retain := lifecyclev1alpha1.RetentionPolicy{FailedJobs: 2}
+
+	if got := failedRetention(retain, 1); got != 2 {
+		t.Fatalf("expected base retention of 2, got %d", got)
+	}
+}
+
+func TestJobResourceRequestsEmptyTemplate(t *testing.T) {
+	jobs := []batchv1.Job{
+		{ObjectMeta: metav1.ObjectMeta{Name: "no-containers"}},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "no-requests"},
+			Spec: batchv1.JobSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "main"}},
+					},
+				},
+			},
+		},
+	}
+
+	reclaimed := sumJobResourceRequests(jobs)
+
+	if len(reclaimed) != 0 {
+		t.Fatalf("expected no reclaimed resources, got %v", reclaimed)
+	}
+}
+
+func TestJobResourceRequests(t *testing.T) {
+	job := batchv1.Job{
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "main",
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("500m"),
+									corev1.ResourceMemory: resource.MustParse("128Mi"),
+								},
+							},
+						},
+						{
+							Name: "sidecar",
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU: resource.MustParse("250m"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	requests := jobResourceRequests(&job)
+
+	cpu := requests[corev1.ResourceCPU]
+	if cpu.MilliValue() != 750 {
+		t.Fatalf("expected 750m cpu, got %s", cpu.String())
+	}
+	memory := requests[corev1.ResourceMemory]
+	if memory.Value() != 128*1024*1024 {
+		t.Fatalf("expected 128Mi memory, got %s", memory.String())
+	}
+}
+
+func TestDropActiveJobs(t *testing.T) {
+	jobs := []batchv1.Job{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "partially-complete"},
+			Status:     batchv1.JobStatus{Active: 1, Succeeded: 1},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "complete"},
+			Status:     batchv1.JobStatus{Succeeded: 2},
+		},
+	}
+
+	inactive := dropActiveJobs(jobs)
+
+	if len(inactive) != 1 || inactive[0].Name != "complete" {
+		t.Fatalf("expected only the complete job, got %d jobs", len(inactive))
+	}
+}
+
+func TestApplyFastRetention(t *testing.T) {
+	fastRetain := 1
+	annotated := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{lifecyclev1alpha1.FastCleanupAnnotation: ""},
+		},
+	}
+
+	spec := lifecyclev1alpha1.CronExecutionCleanerSpec{
+		Retain: lifecyclev1alpha1.RetentionPolicy{SuccessfulJobs: 5, FailedJobs: 5},
+	}
+	if applyFastRetention(&spec, annotated) {
+		t.Fatalf("expected no fast retention without fastRetain")
+	}
+
+	spec.Retain.FastRetain = &fastRetain
+	if applyFastRetention(&spec, &batchv1.CronJob{}) {
+		t.Fatalf("expected no fast retention without the annotation")
+	}
+	if applyFastRetention(&spec, nil) {
+		t.Fatalf("expected no fast retention for a missing CronJob")
+	}
+	if !applyFastRetention(&spec, annotated) {
+		t.Fatalf("expected fast retention to be applied")
+	}
+	if spec.Retain.SuccessfulJobs != 1 || spec.Retain.FailedJobs != 1 {
+		t.Fatalf("expected retention of 1/1, got %d/%d", spec.Retain.SuccessfulJobs, spec.Retain.FailedJobs)
+	}
+}
+
+func TestFilterJobsByOwnerMultipleKinds(t *testing.T) {
+	jobs := []batchv1.Job{
+		{ObjectMeta: metav1.ObjectMeta{
+			Name:            "from-cronjob",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "CronJob", Name: "nightly"}},
+		}},
+		{ObjectMeta: metav1.ObjectMeta{
+			Name:            "from-scheduledjob",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ScheduledJob", Name: "nightly"}},
+		}},
+		{ObjectMeta: metav1.ObjectMeta{
+			Name:            "from-other-kind",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Workflow", Name: "nightly"}},
+		}},
+	}
+
+	owned, _ := filterJobsByOwner(jobs, map[string]types.UID{"nightly": ""}, []string{"CronJob", "ScheduledJob"}, false)
+	filtered := owned["nightly"]
+
+	if len(filtered) != 2 || filtered[0].Name != "from-cronjob" || filtered[1].Name != "from-scheduledjob" {
+		t.Fatalf("expected jobs of both owner kinds, got %v", jobNames(filtered))
+	}
+}
+
+func TestJobFinishedAtActiveJob(t *testing.T) {
+	now := time.Now()
+	job := &batchv1.Job{
+		Status: batchv1.JobStatus{
+			Active:    1,
+			Succeeded: 1,
+			StartTime: &metav1.Time{Time: now.Add(-time.Hour)},
+			Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobComplete, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(now)},
+			},
+		},
+	}
+	if finishedAt := jobFinishedAt(job); finishedAt != nil {
+		t.Fatalf("expected active job to have no finish time, got %v", finishedAt)
+	}
+}
+
+func TestJobsOlderThan(t *testing.T) {
+	now := time.Now()
+	finished := func(name string, ago time.Duration) batchv1.Job {
+		return batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: batchv1.JobStatus{
+				Succeeded:      1,
+				CompletionTime: &metav1.Time{Time: now.Add(-ago)},
+			},
+		}
+	}
+	failed := batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "failed-old"},
+		Status: batchv1.JobStatus{
+			Failed: 1,
+			Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(now.Add(-3 * time.Hour))},
+			},
+		},
+	}
+	// Neither a completion time nor a finished condition
+	unknown := batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "unknown"},
+		Status:     batchv1.JobStatus{Succeeded: 1, StartTime: &metav1.Time{Time: now.Add(-48 * time.Hour)}},
+	}
+	running := batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "running"},
+		Status:     batchv1.JobStatus{Active: 1, StartTime: &metav1.Time{Time: now.Add(-48 * time.Hour)}},
+	}
+
+	jobs := []batchv1.Job{finished("old", 3*time.Hour), finished("new", time.Hour), failed, unknown, running}
+	expired := jobsOlderThan(jobs, 2*time.Hour, now)
+
+	names := jobNames(expired)
+	if !slices.Equal(names, []string{"old", "failed-old"}) {
+		t.Fatalf("expected old and failed-old to be expired, got %v", names)
+	}
+}
+
+func TestJobsOlderThanNilCompletionTime(t *testing.T) {
+	jobs := []batchv1.Job{{ObjectMeta: metav1.ObjectMeta{Name: "no-completion"}, Status: batchv1.JobStatus{Succeeded: 1}}}
+
+	if expired := jobsOlderThan(jobs, 0, time.Now()); len(expired) != 0 {
+		t.Fatalf("expected a job without a finish time never to expire, got %v", jobNames(expired))
+	}
+}
+
+func TestCapStatusListsStaysUnderBudget(t *testing.T) {
+	longName := func(i int) string {
+		return fmt.Sprintf("%s-%05d", strings.Repeat("very-long-cronjob-name", 10), i)
+	}
+	status := lifecyclev1alpha1.CronExecutionCleanerStatus{}
+	for i := 0; i < 2000; i++ {
+		status.StuckJobNames = append(status.StuckJobNames, longName(i))
+		status.PendingForegroundDeletions = append(status.PendingForegroundDeletions, longName(i))
+	}
+
+	if dropped := capStatusLists(&status); dropped == 0 {
+		t.Fatalf("expected entries to be dropped")
+	}
+
+	lists, err := json.Marshal([][]string{status.StuckJobNames, status.PendingForegroundDeletions})
+	if err != nil {
+		t.Fatalf("failed to marshal lists: %v", err)
+	}
+	if len(lists) > statusListBudget {
+		t.Fatalf("expected status lists to fit into %d bytes, got %d", statusListBudget, len(lists))
+	}
+	if len(status.PendingForegroundDeletions) == 0 || status.PendingForegroundDeletions[len(status.PendingForegroundDeletions)-1] != longName(1999) {
+		t.Fatalf("expected the newest entries to be kept")
+	}
+}
+
+func TestCapConditionsDropsLeastRecentlyTransitioned(t *testing.T) {
+	base := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	status := lifecyclev1alpha1.CronExecutionCleanerStatus{}
+	// Ready transitioned first of all, but is always kept
+	for i, conditionType := range []string{"Ready", "A", "B", "C", "D", "E", "F", "G", "H", "I", "J"} {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               conditionType,
+			Status:             metav1.ConditionTrue,
+			Reason:             "Test",
+			LastTransitionTime: metav1.NewTime(base.Add(time.Duration(i) * time.Minute)),
+		})
+	}
+
+	if dropped := capConditions(&status, 0); dropped != 3 {
+		t.Fatalf("expected 3 conditions dropped with the default limit, got %d", dropped)
+	}
+	types := []string{}
+	for _, condition := range status.Conditions {
+		types = append(types, condition.Type)
+	}
+	if want := []string{"Ready", "D", "E", "F", "G", "H", "I", "J"}; !slices.Equal(types, want) {
+		t.Fatalf("expected conditions %v, got %v", want, types)
+	}
+
+	if dropped := capConditions(&status, 2); dropped != 6 || len(status.Conditions) != 2 ||
+		status.Conditions[0].Type != "Ready" || status.Conditions[1].Type != "J" {
+		t.Fatalf("expected Ready and the newest condition to remain, got %+v", status.Conditions)
+	}
+}
+
+func TestPriorityEnqueuerQueuesFailingCleanersFirst(t *testing.T) {
+	cleaner := func(name, failureRatio string) *lifecyclev1alpha1.CronExecutionCleaner {
+		return &lifecyclev1alpha1.CronExecutionCleaner{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Status:     lifecyclev1alpha1.CronExecutionCleanerStatus{FailureRatio: failureRatio},
+		}
+	}
+	q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer q.ShutDown()
+
+	ctx := context.Background()
+	enqueuer := priorityEnqueuer{}
+	enqueuer.Create(ctx, event.CreateEvent{Object: cleaner("healthy", "0.00")}, q)
+	enqueuer.Create(ctx, event.CreateEvent{Object: cleaner("half", "0.50")}, q)
+	enqueuer.Create(ctx, event.CreateEvent{Object: cleaner("failing", "1.00")}, q)
+
+	for _, want := range []string{"failing", "half", "healthy"} {
+		item, _ := q.Get()
+		if req := item.(reconcile.Request); req.Name != want {
+			t.Fatalf("expected %s to be dequeued next, got %s", want, req.Name)
+		}
+		q.Done(item)
+	}
+}
+
+func TestPriorityEnqueuerDoesNotDelayUpdates(t *testing.T) {
+	healthy := &lifecyclev1alpha1.CronExecutionCleaner{
+		ObjectMeta: metav1.ObjectMeta{Name: "healthy", Namespace: "default"},
+		Status:     lifecyclev1alpha1.CronExecutionCleanerStatus{FailureRatio: "0.00"},
+	}
+	q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer q.ShutDown()
+
+	priorityEnqueuer{}.Update(context.Background(), event.UpdateEvent{ObjectOld: healthy, ObjectNew: healthy}, q)
+	if q.Len() != 1 {
+		t.Fatalf("expected the update to be queued right away, got queue length %d", q.Len())
+	}
+}
+
+func TestMaintenanceWindowAt(t *testing.T) {
+	window := &lifecyclev1alpha1.MaintenanceWindow{Start: "23:00", Duration: metav1.Duration{Duration: 2 * time.Hour}}
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		now      time.Time
+		open     bool
+		boundary time.Time
+	}{
+		{day.Add(22 * time.Hour), false, day.Add(23 * time.Hour)},
+		{day.Add(23 * time.Hour), true, day.Add(25 * time.Hour)},
+		// Open across midnight
+		{day.Add(30 * time.Minute), true, day.Add(time.Hour)},
+		{day.Add(time.Hour), false, day.Add(23 * time.Hour)},
+		// Other time zones are compared in UTC
+		{day.Add(22 * time.Hour).In(time.FixedZone("UTC+2", 2*60*60)), false, day.Add(23 * time.Hour)},
+	}
+	for _, c := range cases {
+		open, boundary := maintenanceWindowAt(window, c.now)
+		if open != c.open || !boundary.Equal(c.boundary) {
+			t.Errorf("at %s: expected open=%v until %s, got open=%v until %s", c.now, c.open, c.boundary, open, boundary)
+		}
+	}
+}
+
+func TestProtectExpression(t *testing.T) {
+	labeled := batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "pinned", Labels: map[string]string{"retain": "forever"}}}
+	other := batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "other", Labels: map[string]string{"team": "data"}}}
+	unlabeled := batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "unlabeled"}}
+
+	expr, err := compileProtectExpression(`"retain" in object.metadata.labels && object.metadata.labels["retain"] == "forever"`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The unlabeled Job has no labels field to test, which fails and protects it
+	kept := dropProtectedJobs([]batchv1.Job{labeled, other, unlabeled}, expr)
+	if len(kept) != 1 || kept[0].Name != "other" {
+		t.Fatalf("expected only the other job to be kept, got %v", kept)
+	}
+
+	for _, src := range []string{
+		`object.metadata.labels["retain"] ==`,
+		`object.metadata.name.size()`,
+		`unknown == "x"`,
+	} {
+		if _, err := compileProtectExpression(src); err == nil {
+			t.Errorf("expected %q not to compile", src)
+		}
+	}
+}
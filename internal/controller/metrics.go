@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	batchv1 "k8s.io/api/batch/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	lifecyclev1alpha1 "github.com/bhatpriyanka8/cron-execution-cleaner/api/v1alpha1"
+)
+
+// cleanerLabelNames are the per-object labels dropped by AggregateMetrics.
+var cleanerLabelNames = []string{"namespace", "name"}
+
+var (
+	// statusUpdateFailures counts failed status writes per cleaner, or across
+	// all cleaners once metrics are aggregated
+	statusUpdateFailures = newStatusUpdateFailures(cleanerLabelNames)
+
+	// apiCallsPerReconcile records how many API server calls each reconcile
+	// makes, by verb
+	apiCallsPerReconcile = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "cron_cleaner_apiserver_calls_per_reconcile",
+			Help:    "Number of API server calls made by a single reconcile",
+			Buckets: []float64{0, 1, 2, 5, 10, 20, 50, 100, 200, 500},
+		},
+		[]string{"verb"},
+	)
+
+	// jobsSkipped counts Jobs that a cleanup run left alone, by the reason
+	// they were skipped
+	jobsSkipped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cron_cleaner_jobs_skipped_total",
+			Help: "Number of Jobs left alone by a cleanup run, by skip reason",
+		},
+		[]string{"reason"},
+	)
+
+	// aggregateMetrics is set when the per-object labels are dropped.
+	aggregateMetrics bool
+)
+
+func init() {
+	metrics.Registry.MustRegister(cleanerMetrics{}, apiCallsPerReconcile, jobsSkipped)
+}
+
+// cleanerMetrics collects the metrics labeled by cleaner. Its Describe sends
+// nothing, which makes it an unchecked collector, so AggregateMetrics can swap
+// in metrics with a different label set after registration.
+type cleanerMetrics struct{}
+
+// Describe implements prometheus.Collector.
+func (cleanerMetrics) Describe(chan<- *prometheus.Desc) {}
+
+// Collect implements prometheus.Collector.
+func (cleanerMetrics) Collect(ch chan<- prometheus.Metric) {
+	statusUpdateFailures.Collect(ch)
+}
+
+func newStatusUpdateFailures(labelNames []string) *prometheus.CounterVec {
+	return prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cron_cleaner_status_update_failures_total",
+			Help: "Number of failed CronExecutionCleaner status updates",
+		},
+		labelNames,
+	)
+}
+
+// AggregateMetrics drops the namespace and name labels from the controller
+// metrics, trading per-object detail for bounded cardinality. It must be
+// called before the manager starts.
+func AggregateMetrics() {
+	setAggregateMetrics(true)
+}
+
+func setAggregateMetrics(aggregate bool) {
+	labelNames := cleanerLabelNames
+	if aggregate {
+		labelNames = nil
+	}
+	statusUpdateFailures = newStatusUpdateFailures(labelNames)
+	aggregateMetrics = aggregate
+}
+
+// cleanerLabels returns the per-object labels of the cleaner, or none when
+// metrics are aggregated.
+func cleanerLabels(cleaner *lifecyclev1alpha1.CronExecutionCleaner) prometheus.Labels {
+	if aggregateMetrics {
+		return prometheus.Labels{}
+	}
+	return prometheus.Labels{"namespace": cleaner.Namespace, "name": cleaner.Name}
+}
+
+// recordSkipped counts the Jobs dropped by a filter under the given skip
+// reason and returns the Jobs it kept.
+func recordSkipped(reason string, jobs, kept []batchv1.Job) []batchv1.Job {
+	if skipped := len(jobs) - len(kept); skipped > 0 {
+		jobsSkipped.WithLabelValues(reason).Add(float64(skipped))
+	}
+	return kept
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	lifecyclev1alpha1 "github.com/bhatpriyanka8/cron-execution-cleaner/api/v1alpha1"
+)
+
+var (
+	objectJobsDeletedDesc = prometheus.NewDesc(
+		"cron_cleaner_object_jobs_deleted_total",
+		"Number of Jobs deleted by a CronExecutionCleaner",
+		[]string{"namespace", "name"}, nil,
+	)
+	objectPodsDeletedDesc = prometheus.NewDesc(
+		"cron_cleaner_object_pods_deleted_total",
+		"Number of Pods deleted by a CronExecutionCleaner",
+		[]string{"namespace", "name"}, nil,
+	)
+	objectStuckJobsDesc = prometheus.NewDesc(
+		"cron_cleaner_object_stuck_jobs",
+		"Number of stuck Jobs found in the last run of a CronExecutionCleaner",
+		[]string{"namespace", "name"}, nil,
+	)
+	objectPhaseDesc = prometheus.NewDesc(
+		"cron_cleaner_object_phase",
+		"Current phase of a CronExecutionCleaner, 1 for the active phase",
+		[]string{"namespace", "name", "phase"}, nil,
+	)
+)
+
+// cleanerPhases are the phases reported for every cleaner.
+var cleanerPhases = []lifecyclev1alpha1.CleanerPhase{
+	lifecyclev1alpha1.PhaseIdle,
+	lifecyclev1alpha1.PhaseCleaning,
+	lifecyclev1alpha1.PhaseSuspended,
+	lifecyclev1alpha1.PhaseWaiting,
+	lifecyclev1alpha1.PhaseError,
+	lifecyclev1alpha1.PhaseInvalid,
+}
+
+// ObjectMetrics keeps the latest status of each cleaner seen by the
+// reconciler and exposes it as per-object series.
+type ObjectMetrics struct {
+	mu      sync.RWMutex
+	objects map[types.NamespacedName]lifecyclev1alpha1.CronExecutionCleanerStatus
+}
+
+// NewObjectMetrics returns an empty ObjectMetrics.
+func NewObjectMetrics() *ObjectMetrics {
+	return &ObjectMetrics{
+		objects: map[types.NamespacedName]lifecyclev1alpha1.CronExecutionCleanerStatus{},
+	}
+}
+
+// Handler serves the per-object series, in OpenMetrics format when the
+// scraper asks for it.
+func (m *ObjectMetrics) Handler() http.Handler {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(m)
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{EnableOpenMetrics: true})
+}
+
+func (m *ObjectMetrics) record(cleaner *lifecyclev1alpha1.CronExecutionCleaner) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.objects[client.ObjectKeyFromObject(cleaner)] = *cleaner.Status.DeepCopy()
+}
+
+func (m *ObjectMetrics) forget(key types.NamespacedName) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.objects, key)
+}
+
+// Describe implements prometheus.Collector.
+func (m *ObjectMetrics) Describe(ch chan<- *prometheus.Desc) {
+	ch <- objectJobsDeletedDesc
+	ch <- objectPodsDeletedDesc
+	ch <- objectStuckJobsDesc
+	ch <- objectPhaseDesc
+}
+
+// Collect implements prometheus.Collector.
+func (m *ObjectMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for key, status := range m.objects {
+		ch <- prometheus.MustNewConstMetric(objectJobsDeletedDesc, prometheus.CounterValue,
+			float64(status.JobsDeleted), key.Namespace, key.Name)
+		ch <- prometheus.MustNewConstMetric(objectPodsDeletedDesc, prometheus.CounterValue,
+			float64(status.PodsDeleted), key.Namespace, key.Name)
+		ch <- prometheus.MustNewConstMetric(objectStuckJobsDesc, prometheus.GaugeValue,
+			float64(status.StuckJobs), key.Namespace, key.Name)
+		for _, phase := range cleanerPhases {
+			active := 0.0
+			if status.Phase == phase {
+				active = 1
+			}
+			ch <- prometheus.MustNewConstMetric(objectPhaseDesc, prometheus.GaugeValue,
+				active, key.Namespace, key.Name, string(phase))
+		}
+	}
+}
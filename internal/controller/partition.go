@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	lifecyclev1alpha1 "github.com/bhatpriyanka8/cron-execution-cleaner/api/v1alpha1"
+)
+
+// cleanerPartition is a share of the cleaners that is reconciled by its own
+// controller, with its own work queue and workers.
+type cleanerPartition struct {
+	name  string
+	large bool
+}
+
+// partitions returns the controllers the cleaners are split across. Unless
+// LargeCleanerJobs is set, all cleaners share a single controller.
+func (r *CronExecutionCleanerReconciler) partitions() []cleanerPartition {
+	small := cleanerPartition{name: "cronexecutioncleaner"}
+	if r.LargeCleanerJobs <= 0 {
+		return []cleanerPartition{small}
+	}
+	return []cleanerPartition{small, {name: "cronexecutioncleaner-large", large: true}}
+}
+
+// isLargeCleaner reports whether the cleaner owned at least LargeCleanerJobs
+// Jobs in its last run.
+func (r *CronExecutionCleanerReconciler) isLargeCleaner(obj client.Object) bool {
+	cleaner, ok := obj.(*lifecyclev1alpha1.CronExecutionCleaner)
+	return ok && r.LargeCleanerJobs > 0 && cleaner.Status.OwnedJobCount >= r.LargeCleanerJobs
+}
+
+// partitionPredicate admits events for the cleaners in the partition.
+func (r *CronExecutionCleanerReconciler) partitionPredicate(p cleanerPartition) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return r.isLargeCleaner(obj) == p.large
+	})
+}
+
+// partitionReconciler reconciles the cleaners in one partition. A cleaner
+// that moved to the other partition since it was queued, e.g. by a requeue
+// or a pause ConfigMap change, is left to that partition's controller, which
+// is queued by the status update that moved it.
+type partitionReconciler struct {
+	*CronExecutionCleanerReconciler
+	partition cleanerPartition
+}
+
+// Reconcile implements reconcile.Reconciler.
+func (p partitionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if p.LargeCleanerJobs > 0 {
+		var cleaner lifecyclev1alpha1.CronExecutionCleaner
+		if err := p.Get(ctx, req.NamespacedName, &cleaner); err == nil && p.isLargeCleaner(&cleaner) != p.partition.large {
+			return ctrl.Result{}, nil
+		}
+	}
+	return p.CronExecutionCleanerReconciler.Reconcile(ctx, req)
+}
@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	lifecyclev1alpha1 "github.com/bhatpriyanka8/cron-execution-cleaner/api/v1alpha1"
+)
+
+// pausedKey is the key in the pause ConfigMap that pauses every cleaner when
+// set to "true".
+const pausedKey = "paused"
+
+// globallyPaused reports whether the pause ConfigMap asks for every cleaner to
+// be paused. A missing ConfigMap means not paused. Any other read error is
+// returned, so that cleanup does not resume while the pause cannot be checked.
+func (r *CronExecutionCleanerReconciler) globallyPaused(ctx context.Context) (bool, error) {
+	if r.PauseConfigMap.Name == "" {
+		return false, nil
+	}
+	var cm corev1.ConfigMap
+	if err := r.Get(ctx, r.PauseConfigMap, &cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return cm.Data[pausedKey] == "true", nil
+}
+
+// enqueueAllCleaners queues every cleaner when the pause ConfigMap changes, so
+// that pausing and resuming take effect without waiting for the next run.
+func (r *CronExecutionCleanerReconciler) enqueueAllCleaners(ctx context.Context, obj client.Object) []reconcile.Request {
+	if obj.GetNamespace() != r.PauseConfigMap.Namespace || obj.GetName() != r.PauseConfigMap.Name {
+		return nil
+	}
+	var cleaners lifecyclev1alpha1.CronExecutionCleanerList
+	if err := r.List(ctx, &cleaners); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list cleaners after the pause ConfigMap changed")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(cleaners.Items))
+	for i := range cleaners.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cleaners.Items[i])})
+	}
+	return requests
+}
@@ -0,0 +1,298 @@
+package controller
+
+import (
+	"regexp"
+	"slices"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	lifecyclev1alpha1 "github.com/bhatpriyanka8/cron-execution-cleaner/api/v1alpha1"
+)
+
+// DeletionPlan describes which Jobs a cleanup run deletes and why. It is
+// computed without side effects, so it can be inspected or logged before
+// anything is deleted.
+type DeletionPlan struct {
+	// Jobs owned by the target CronJobs, by state
+	Active    []batchv1.Job
+	Succeeded []batchv1.Job
+	Failed    []batchv1.Job
+
+	// Jobs naming a target CronJob whose owner reference points at a
+	// different object, e.g. a CronJob of the same name in another namespace.
+	// They are left alone.
+	Foreign []batchv1.Job
+
+	// Jobs selected for deletion, by reason. Abandoned Jobs are active Jobs
+	// past cleanupStuck.maxAge; they are not part of Stuck.
+	Stuck           []batchv1.Job
+	Abandoned       []batchv1.Job
+	ExcessSucceeded []batchv1.Job
+	ExcessFailed    []batchv1.Job
+
+	// Number of failed Jobs retained per CronJob after any elevation, for
+	// the share of failed Jobs across all target CronJobs
+	RetainFailed int
+
+	// Share of failed Jobs among completed Jobs of all target CronJobs
+	FailureRatio float64
+
+	// Number of Jobs left out of the plan, by skip reason
+	Skipped map[string]int
+}
+
+// Owned returns the number of Jobs owned by the target CronJobs.
+func (p DeletionPlan) Owned() int {
+	return len(p.Active) + len(p.Succeeded) + len(p.Failed)
+}
+
+// add merges the plan for another target CronJob into p.
+func (p *DeletionPlan) add(other DeletionPlan) {
+	p.Active = append(p.Active, other.Active...)
+	p.Succeeded = append(p.Succeeded, other.Succeeded...)
+	p.Failed = append(p.Failed, other.Failed...)
+	p.Stuck = append(p.Stuck, other.Stuck...)
+	p.Abandoned = append(p.Abandoned, other.Abandoned...)
+	p.ExcessSucceeded = append(p.ExcessSucceeded, other.ExcessSucceeded...)
+	p.ExcessFailed = append(p.ExcessFailed, other.ExcessFailed...)
+	for reason, count := range other.Skipped {
+		p.Skipped[reason] += count
+	}
+}
+
+// planDeletions decides which of the given Jobs should be deleted for the
+// cleaner at the given time. cronJobs holds the target CronJobs by name; a
+// CronJob that is not known is missing from it.
+func planDeletions(
+	cleaner *lifecyclev1alpha1.CronExecutionCleaner,
+	cronJobs map[string]*batchv1.CronJob,
+	jobs []batchv1.Job,
+	now time.Time,
+) DeletionPlan {
+	spec := cleaner.Spec
+	plan := DeletionPlan{Skipped: map[string]int{}}
+	names := targetCronJobNames(&spec)
+
+	// Excluded Jobs are dropped before anything else looks at them. The
+	// expression has been validated along with the rest of the spec.
+	if spec.ExcludeNameRegex != "" {
+		if re, err := regexp.Compile(spec.ExcludeNameRegex); err == nil {
+			kept := dropJobsMatchingName(jobs, re)
+			plan.Skipped["excluded"] += len(jobs) - len(kept)
+			jobs = kept
+		}
+	}
+	if spec.ProtectExpression != "" {
+		if expr, err := compileProtectExpression(spec.ProtectExpression); err == nil {
+			kept := dropProtectedJobs(jobs, expr)
+			plan.Skipped["protected"] += len(jobs) - len(kept)
+			jobs = kept
+		}
+	}
+
+	// Jobs selected by the CronJob's job template labels, or by the selector
+	// alone, are owned by definition; the caller has already narrowed them
+	// down. Validation allows a single target CronJob in that case.
+	var ownedJobs map[string][]batchv1.Job
+	switch {
+	case spec.UseJobTemplateLabels:
+		if len(names) > 0 {
+			ownedJobs = map[string][]batchv1.Job{names[0]: jobs}
+		}
+	case len(names) == 0 && spec.Selector != nil:
+		names = []string{""}
+		ownedJobs = map[string][]batchv1.Job{"": jobs}
+	default:
+		owners := map[string]types.UID{}
+		for _, name := range names {
+			owners[name] = ""
+			if cronJob := cronJobs[name]; cronJob != nil {
+				owners[name] = cronJob.UID
+			}
+		}
+		ownedJobs, plan.Foreign = filterJobsByOwner(jobs, owners, spec.OwnerKinds, spec.RequireControllerOwner)
+	}
+
+	// Retention counts apply to each CronJob on its own
+	for _, name := range names {
+		plan.add(planTarget(spec, cronJobs[name], ownedJobs[name], now))
+	}
+	plan.FailureRatio = failureRatio(len(plan.Succeeded), len(plan.Failed))
+	plan.RetainFailed = failedRetention(spec.Retain, plan.FailureRatio)
+	if spec.Retain.PrioritizeHighResource {
+		plan.ExcessFailed = sortByResourceRequests(plan.ExcessFailed)
+	}
+
+	return plan
+}
+
+// planTarget plans the deletions among the Jobs owned by a single target
+// CronJob, or nil when the CronJob is not known.
+func planTarget(
+	spec lifecyclev1alpha1.CronExecutionCleanerSpec,
+	cronJob *batchv1.CronJob,
+	ownedJobs []batchv1.Job,
+	now time.Time,
+) DeletionPlan {
+	plan := DeletionPlan{Skipped: map[string]int{}}
+
+	// Jobs someone else is already deleting are neither counted nor deleted
+	// again
+	settled := dropTerminatingJobs(ownedJobs)
+	plan.Skipped["terminating"] = len(ownedJobs) - len(settled)
+	plan.Active, plan.Succeeded, plan.Failed = classifyJobs(settled)
+	plan.FailureRatio = failureRatio(len(plan.Succeeded), len(plan.Failed))
+	plan.RetainFailed = failedRetention(spec.Retain, plan.FailureRatio)
+	if spec.Retain.AlignWithCronJobHistoryLimit && cronJob != nil {
+		// Keeping more than the CronJob controller does only fights it
+		successful, failed := cronJobHistoryLimits(cronJob)
+		spec.Retain.SuccessfulJobs = stricterRetention(spec.Retain.SuccessfulJobs, successful)
+		plan.RetainFailed = stricterRetention(plan.RetainFailed, failed)
+	}
+
+	// Stuck detection and retention are gated independently
+	if spec.CleanupStuck.Enabled {
+		if spec.CleanupStuck.UsePodConditionAge {
+			// Every active Job is a candidate until the reconciler has
+			// looked at the conditions of its Pods
+			plan.Stuck = slices.Clone(plan.Active)
+		} else {
+			plan.Stuck = detectStuckJobs(plan.Active, spec.CleanupStuck.StuckAfter.Duration, now)
+		}
+		if spec.CleanupStuck.RespectStartingDeadline {
+			plan.Stuck = dropJobsWithinStartingDeadline(plan.Stuck, cronJob, now)
+		}
+		if maxAge := spec.CleanupStuck.MaxAge; maxAge != nil {
+			plan.Abandoned = detectStuckJobs(plan.Active, maxAge.Duration, now)
+			plan.Stuck = withoutJobs(plan.Stuck, plan.Abandoned)
+		}
+		if spec.CleanupStuck.Action == lifecyclev1alpha1.StuckActionQuarantine {
+			plan.Stuck = dropQuarantinedJobs(plan.Stuck, spec.CleanupStuck.QuarantineLabel)
+		}
+		if spec.CleanupStuck.MaxAgeAction == lifecyclev1alpha1.StuckActionQuarantine {
+			plan.Abandoned = dropQuarantinedJobs(plan.Abandoned, spec.CleanupStuck.QuarantineLabel)
+		}
+	}
+
+	// Jobs still inside their grace period do not count against retention
+	succeeded := dropJobsInGrace(plan.Succeeded, spec.Retain.SuccessfulGrace, now)
+	failed := dropJobsInGrace(plan.Failed, spec.Retain.FailedGrace, now)
+	plan.Skipped["grace"] += len(plan.Succeeded) - len(succeeded) + len(plan.Failed) - len(failed)
+
+	// Manually triggered Jobs form a retention pool of their own
+	var manualSucceeded, manualFailed []batchv1.Job
+	if spec.Retain.ManualJobs != nil {
+		succeeded, manualSucceeded = splitManualJobs(succeeded)
+		failed, manualFailed = splitManualJobs(failed)
+	}
+
+	// Retention never touches Jobs that still have active Pods
+	plan.ExcessSucceeded = dropActiveJobs(excessBatches(succeeded, spec.Retain.SuccessfulJobs, spec.Retain.AtomicByLabel))
+	if spec.Retain.DaysToKeep > 0 {
+		keep := keptPerDay(succeeded, spec.Retain.PerDay, spec.Retain.DaysToKeep, now)
+		excess := []batchv1.Job{}
+		for _, job := range plan.ExcessSucceeded {
+			if !keep[job.Name] {
+				excess = append(excess, job)
+			}
+		}
+		plan.ExcessSucceeded = excess
+	}
+	plan.ExcessFailed = dropActiveJobs(excessBatches(failed, plan.RetainFailed, spec.Retain.AtomicByLabel))
+	if spec.Retain.ManualJobs != nil {
+		manual := append(slices.Clone(manualSucceeded), manualFailed...)
+		_, excessSucceeded, excessFailed := classifyJobs(dropActiveJobs(excessJobs(manual, *spec.Retain.ManualJobs)))
+		plan.ExcessSucceeded = append(plan.ExcessSucceeded, excessSucceeded...)
+		plan.ExcessFailed = append(plan.ExcessFailed, excessFailed...)
+	}
+	if label := spec.Retain.CurrentGenerationLabel; label != "" {
+		// Jobs of older generations go whatever the retention counts say
+		owned := append(append(slices.Clone(plan.Active), plan.Succeeded...), plan.Failed...)
+		older := olderGenerationJobs(owned, label, spec.Retain.KeepPreviousGenerations)
+		for _, job := range dropActiveJobs(succeeded) {
+			if older[job.Name] && !slices.Contains(jobNames(plan.ExcessSucceeded), job.Name) {
+				plan.ExcessSucceeded = append(plan.ExcessSucceeded, job)
+			}
+		}
+		for _, job := range dropActiveJobs(failed) {
+			if older[job.Name] && !slices.Contains(jobNames(plan.ExcessFailed), job.Name) {
+				plan.ExcessFailed = append(plan.ExcessFailed, job)
+			}
+		}
+	}
+	// Jobs past their TTL go whatever the retention counts say
+	if ttl := spec.Retain.SuccessfulTTL; ttl != nil {
+		completed := append(slices.Clone(succeeded), manualSucceeded...)
+		for _, job := range jobsOlderThan(completed, ttl.Duration, now) {
+			if !slices.Contains(jobNames(plan.ExcessSucceeded), job.Name) {
+				plan.ExcessSucceeded = append(plan.ExcessSucceeded, job)
+			}
+		}
+	}
+	if ttl := spec.Retain.FailedTTL; ttl != nil {
+		completed := append(slices.Clone(failed), manualFailed...)
+		for _, job := range jobsOlderThan(completed, ttl.Duration, now) {
+			if !slices.Contains(jobNames(plan.ExcessFailed), job.Name) {
+				plan.ExcessFailed = append(plan.ExcessFailed, job)
+			}
+		}
+	}
+	if spec.Retain.FailedMessageContains != "" {
+		plan.ExcessFailed = keepJobsFailedWith(plan.ExcessFailed, spec.Retain.FailedMessageContains)
+	}
+
+	return plan
+}
+
+// Impact summarizes what a cleanup run with the given spec would do to the
+// given Jobs, without deleting anything. Deletions are counted by reason
+// (stuck, succeeded, failed) and take the per-run deletion cap in the order
+// the controller does: abandoned, stuck, succeeded, then failed Jobs. Stuck
+// Jobs that are quarantined and Jobs a read-only cleaner or a dry run would
+// only report are not counted as deletions.
+// Checks that need to read Pods or PersistentVolumeClaims are not applied,
+// so with usePodConditionAge every active Job counts as stuck.
+// With UseJobTemplateLabels or Selector set, jobs must already be narrowed
+// down to those carrying the matching labels.
+func Impact(
+	spec lifecyclev1alpha1.CronExecutionCleanerSpec,
+	jobs []batchv1.Job,
+) (toDelete, toRetain int, byReason map[string]int) {
+	cleaner := &lifecyclev1alpha1.CronExecutionCleaner{Spec: spec}
+	cleaner.Spec = EffectiveSpec(cleaner)
+
+	plan := planDeletions(cleaner, nil, jobs, time.Now())
+	if cleaner.Spec.CleanupStuck.ReportOnly || !cleaner.Spec.CleanupStuck.Enabled {
+		plan.Stuck = nil
+		plan.Abandoned = nil
+	}
+
+	byReason = map[string]int{"stuck": 0, "succeeded": 0, "failed": 0}
+	if cleaner.Spec.ReadOnly {
+		return 0, plan.Owned(), byReason
+	}
+	budget := newDeletionBudget(cleaner.Spec.MaxDeletionsPerRun)
+	// deleted takes the budget like the controller, dry run or not, and
+	// counts the Jobs that are actually deleted
+	deleted := func(jobs []batchv1.Job, reason string) int {
+		taken := len(budget.take(jobs))
+		if dryRunFor(&cleaner.Spec, reason) {
+			return 0
+		}
+		return taken
+	}
+	if cleaner.Spec.CleanupStuck.MaxAgeAction != lifecyclev1alpha1.StuckActionQuarantine {
+		byReason["stuck"] += deleted(plan.Abandoned, "abandoned")
+	}
+	if cleaner.Spec.CleanupStuck.Action != lifecyclev1alpha1.StuckActionQuarantine {
+		byReason["stuck"] += deleted(plan.Stuck, "stuck")
+	}
+	byReason["succeeded"] = deleted(plan.ExcessSucceeded, "succeeded")
+	byReason["failed"] = deleted(plan.ExcessFailed, "failed")
+	for _, count := range byReason {
+		toDelete += count
+	}
+	return toDelete, plan.Owned() - toDelete, byReason
+}
@@ -0,0 +1,563 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	lifecyclev1alpha1 "github.com/bhatpriyanka8/cron-execution-cleaner/api/v1alpha1"
+)
+
+func planJob(name string, status batchv1.JobStatus) batchv1.Job {
+	return batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "CronJob", Name: "my-cronjob"},
+			},
+		},
+		Status: status,
+	}
+}
+
+func labeledPlanJob(job batchv1.Job, labels map[string]string) batchv1.Job {
+	job.Labels = labels
+	return job
+}
+
+func manualPlanJob(job batchv1.Job) batchv1.Job {
+	job.Annotations = map[string]string{"cronjob.kubernetes.io/instantiate": "manual"}
+	return job
+}
+
+func failedCondition(message string) batchv1.JobCondition {
+	return batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: message}
+}
+
+func sameNames(got []batchv1.Job, want []string) bool {
+	names := jobNames(got)
+	if len(names) != len(want) {
+		return false
+	}
+	for i := range names {
+		if names[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPlanDeletions(t *testing.T) {
+	now := time.Now()
+	started := func(ago time.Duration) *metav1.Time {
+		return &metav1.Time{Time: now.Add(-ago)}
+	}
+
+	tests := []struct {
+		name            string
+		spec            func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec)
+		jobs            []batchv1.Job
+		stuck           []string
+		excessSucceeded []string
+		excessFailed    []string
+	}{
+		{
+			name: "retention keeps newest jobs",
+			jobs: []batchv1.Job{
+				planJob("succeeded-old", batchv1.JobStatus{Succeeded: 1, StartTime: started(3 * time.Hour)}),
+				planJob("succeeded-new", batchv1.JobStatus{Succeeded: 1, StartTime: started(time.Hour)}),
+				planJob("failed-old", batchv1.JobStatus{Failed: 1, StartTime: started(3 * time.Hour)}),
+				planJob("failed-new", batchv1.JobStatus{Failed: 1, StartTime: started(time.Hour)}),
+			},
+			stuck:           []string{},
+			excessSucceeded: []string{"succeeded-old"},
+			excessFailed:    []string{"failed-old"},
+		},
+		{
+			name: "stuck jobs past threshold",
+			jobs: []batchv1.Job{
+				planJob("running-long", batchv1.JobStatus{Active: 1, StartTime: started(2 * time.Hour)}),
+				planJob("running-short", batchv1.JobStatus{Active: 1, StartTime: started(10 * time.Minute)}),
+			},
+			stuck:           []string{"running-long"},
+			excessSucceeded: []string{},
+			excessFailed:    []string{},
+		},
+		{
+			name: "jobs of other cronjobs are ignored",
+			jobs: []batchv1.Job{
+				planJob("succeeded-old", batchv1.JobStatus{Succeeded: 1, StartTime: started(3 * time.Hour)}),
+				planJob("succeeded-new", batchv1.JobStatus{Succeeded: 1, StartTime: started(time.Hour)}),
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "foreign",
+						OwnerReferences: []metav1.OwnerReference{{Kind: "CronJob", Name: "other"}},
+					},
+					Status: batchv1.JobStatus{Succeeded: 1, StartTime: started(5 * time.Hour)},
+				},
+			},
+			stuck:           []string{},
+			excessSucceeded: []string{"succeeded-old"},
+			excessFailed:    []string{},
+		},
+		{
+			name: "jobs with active pods are protected from retention",
+			jobs: []batchv1.Job{
+				planJob("partially-complete", batchv1.JobStatus{Active: 1, Succeeded: 1, StartTime: started(30 * time.Minute)}),
+				planJob("succeeded-old", batchv1.JobStatus{Succeeded: 1, StartTime: started(3 * time.Hour)}),
+				planJob("succeeded-new", batchv1.JobStatus{Succeeded: 1, StartTime: started(time.Hour)}),
+			},
+			stuck:           []string{},
+			excessSucceeded: []string{"succeeded-old"},
+			excessFailed:    []string{},
+		},
+		{
+			name: "grace periods apply per state",
+			spec: func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+				spec.Retain.SuccessfulJobs = 0
+				spec.Retain.FailedJobs = 0
+				spec.Retain.SuccessfulGrace = &metav1.Duration{Duration: 10 * time.Minute}
+				spec.Retain.FailedGrace = &metav1.Duration{Duration: 2 * time.Hour}
+			},
+			jobs: []batchv1.Job{
+				planJob("succeeded-recent", batchv1.JobStatus{
+					Succeeded: 1, StartTime: started(10 * time.Minute), CompletionTime: started(5 * time.Minute),
+				}),
+				planJob("succeeded-settled", batchv1.JobStatus{
+					Succeeded: 1, StartTime: started(time.Hour), CompletionTime: started(30 * time.Minute),
+				}),
+				planJob("failed-recent", batchv1.JobStatus{
+					Failed: 1, StartTime: started(time.Hour), Conditions: []batchv1.JobCondition{
+						{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, LastTransitionTime: *started(30 * time.Minute)},
+					},
+				}),
+				planJob("failed-settled", batchv1.JobStatus{
+					Failed: 1, StartTime: started(4 * time.Hour), Conditions: []batchv1.JobCondition{
+						{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, LastTransitionTime: *started(3 * time.Hour)},
+					},
+				}),
+			},
+			stuck:           []string{},
+			excessSucceeded: []string{"succeeded-settled"},
+			excessFailed:    []string{"failed-settled"},
+		},
+		{
+			name: "active jobs without completion time are never excess",
+			spec: func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+				spec.Retain.SuccessfulJobs = 0
+				spec.Retain.DaysToKeep = 1
+				spec.Retain.SuccessfulGrace = &metav1.Duration{Duration: time.Minute}
+				spec.CleanupStuck.StuckAfter = metav1.Duration{Duration: 24 * time.Hour}
+			},
+			jobs: []batchv1.Job{
+				planJob("still-running", batchv1.JobStatus{Active: 1, Succeeded: 1, StartTime: started(3 * time.Hour)}),
+				planJob("succeeded-old", batchv1.JobStatus{
+					Succeeded: 1, StartTime: started(49 * time.Hour), CompletionTime: started(48 * time.Hour),
+				}),
+			},
+			stuck:           []string{},
+			excessSucceeded: []string{"succeeded-old"},
+			excessFailed:    []string{},
+		},
+		{
+			name: "keep-all sentinel never deletes succeeded jobs",
+			spec: func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+				spec.Retain.SuccessfulJobs = lifecyclev1alpha1.RetainAll
+			},
+			jobs: []batchv1.Job{
+				planJob("succeeded-oldest", batchv1.JobStatus{Succeeded: 1, StartTime: started(5 * time.Hour)}),
+				planJob("succeeded-old", batchv1.JobStatus{Succeeded: 1, StartTime: started(3 * time.Hour)}),
+				planJob("succeeded-new", batchv1.JobStatus{Succeeded: 1, StartTime: started(time.Hour)}),
+				planJob("failed-old", batchv1.JobStatus{Failed: 1, StartTime: started(3 * time.Hour)}),
+				planJob("failed-new", batchv1.JobStatus{Failed: 1, StartTime: started(time.Hour)}),
+			},
+			stuck:           []string{},
+			excessSucceeded: []string{},
+			excessFailed:    []string{"failed-old"},
+		},
+		{
+			name: "jobs matching the exclusion regex are ignored",
+			spec: func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+				spec.ExcludeNameRegex = ".*-canary-.*"
+			},
+			jobs: []batchv1.Job{
+				planJob("report-canary-old", batchv1.JobStatus{Succeeded: 1, StartTime: started(5 * time.Hour)}),
+				planJob("report-canary-stuck", batchv1.JobStatus{Active: 1, StartTime: started(5 * time.Hour)}),
+				planJob("report-old", batchv1.JobStatus{Succeeded: 1, StartTime: started(3 * time.Hour)}),
+				planJob("report-new", batchv1.JobStatus{Succeeded: 1, StartTime: started(time.Hour)}),
+			},
+			stuck:           []string{},
+			excessSucceeded: []string{"report-old"},
+			excessFailed:    []string{},
+		},
+		{
+			name: "jobs matching the protect expression are ignored",
+			spec: func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+				spec.ProtectExpression = `has(object.metadata.labels) && object.metadata.labels["retain"] == "forever"`
+			},
+			jobs: []batchv1.Job{
+				labeledPlanJob(planJob("report-pinned", batchv1.JobStatus{Succeeded: 1, StartTime: started(5 * time.Hour)}),
+					map[string]string{"retain": "forever"}),
+				labeledPlanJob(planJob("report-stuck", batchv1.JobStatus{Active: 1, StartTime: started(5 * time.Hour)}),
+					map[string]string{"retain": "forever"}),
+				planJob("report-old", batchv1.JobStatus{Succeeded: 1, StartTime: started(3 * time.Hour)}),
+				planJob("report-new", batchv1.JobStatus{Succeeded: 1, StartTime: started(time.Hour)}),
+			},
+			stuck:           []string{},
+			excessSucceeded: []string{"report-old"},
+			excessFailed:    []string{},
+		},
+		{
+			name: "atomic retention deletes whole older batches",
+			spec: func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+				spec.Retain.SuccessfulJobs = 1
+				spec.Retain.AtomicByLabel = "batch-id"
+			},
+			jobs: []batchv1.Job{
+				labeledPlanJob(planJob("batch-a-1", batchv1.JobStatus{Succeeded: 1, StartTime: started(4 * time.Hour)}),
+					map[string]string{"batch-id": "a"}),
+				labeledPlanJob(planJob("batch-a-2", batchv1.JobStatus{Succeeded: 1, StartTime: started(3 * time.Hour)}),
+					map[string]string{"batch-id": "a"}),
+				labeledPlanJob(planJob("batch-b-1", batchv1.JobStatus{Succeeded: 1, StartTime: started(2 * time.Hour)}),
+					map[string]string{"batch-id": "b"}),
+				labeledPlanJob(planJob("batch-b-2", batchv1.JobStatus{Succeeded: 1, StartTime: started(time.Hour)}),
+					map[string]string{"batch-id": "b"}),
+			},
+			stuck:           []string{},
+			excessSucceeded: []string{"batch-a-2", "batch-a-1"},
+			excessFailed:    []string{},
+		},
+		{
+			name: "jobs of generations before the kept ones are deleted",
+			spec: func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+				spec.Retain.SuccessfulJobs = lifecyclev1alpha1.RetainAll
+				spec.Retain.FailedJobs = lifecyclev1alpha1.RetainAll
+				spec.Retain.CurrentGenerationLabel = "deploy-generation"
+				spec.Retain.KeepPreviousGenerations = 1
+			},
+			jobs: []batchv1.Job{
+				planJob("unlabeled", batchv1.JobStatus{Succeeded: 1, StartTime: started(7 * time.Hour)}),
+				labeledPlanJob(planJob("gen-1-succeeded", batchv1.JobStatus{Succeeded: 1, StartTime: started(6 * time.Hour)}),
+					map[string]string{"deploy-generation": "1"}),
+				labeledPlanJob(planJob("gen-1-failed", batchv1.JobStatus{Failed: 1, StartTime: started(5 * time.Hour)}),
+					map[string]string{"deploy-generation": "1"}),
+				labeledPlanJob(planJob("gen-2-succeeded", batchv1.JobStatus{Succeeded: 1, StartTime: started(4 * time.Hour)}),
+					map[string]string{"deploy-generation": "2"}),
+				labeledPlanJob(planJob("gen-3-succeeded", batchv1.JobStatus{Succeeded: 1, StartTime: started(2 * time.Hour)}),
+					map[string]string{"deploy-generation": "3"}),
+				labeledPlanJob(planJob("gen-3-running", batchv1.JobStatus{Active: 1, StartTime: started(30 * time.Minute)}),
+					map[string]string{"deploy-generation": "3"}),
+			},
+			stuck:           []string{},
+			excessSucceeded: []string{"gen-1-succeeded"},
+			excessFailed:    []string{"gen-1-failed"},
+		},
+		{
+			name: "manual jobs are retained apart from scheduled ones",
+			spec: func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+				spec.Retain.SuccessfulJobs = 1
+				spec.Retain.FailedJobs = 1
+				spec.Retain.ManualJobs = ptr.To(1)
+			},
+			jobs: []batchv1.Job{
+				planJob("scheduled-old", batchv1.JobStatus{Succeeded: 1, StartTime: started(5 * time.Hour)}),
+				manualPlanJob(planJob("manual-old", batchv1.JobStatus{Failed: 1, StartTime: started(4 * time.Hour)})),
+				manualPlanJob(planJob("manual-older", batchv1.JobStatus{Succeeded: 1, StartTime: started(6 * time.Hour)})),
+				planJob("scheduled-new", batchv1.JobStatus{Succeeded: 1, StartTime: started(3 * time.Hour)}),
+				manualPlanJob(planJob("manual-new", batchv1.JobStatus{Succeeded: 1, StartTime: started(time.Hour)})),
+				planJob("scheduled-failed", batchv1.JobStatus{Failed: 1, StartTime: started(2 * time.Hour)}),
+			},
+			stuck:           []string{},
+			excessSucceeded: []string{"scheduled-old", "manual-older"},
+			excessFailed:    []string{"manual-old"},
+		},
+		{
+			name: "failed message filter only targets matching jobs",
+			spec: func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+				spec.Retain.FailedJobs = 0
+				spec.Retain.FailedMessageContains = "evicted"
+			},
+			jobs: []batchv1.Job{
+				planJob("failed-evicted", batchv1.JobStatus{Failed: 1, StartTime: started(3 * time.Hour),
+					Conditions: []batchv1.JobCondition{failedCondition("The node was low on memory, pod evicted")}}),
+				planJob("failed-backoff", batchv1.JobStatus{Failed: 1, StartTime: started(2 * time.Hour),
+					Conditions: []batchv1.JobCondition{failedCondition("Job has reached the specified backoff limit")}}),
+			},
+			stuck:           []string{},
+			excessSucceeded: []string{},
+			excessFailed:    []string{"failed-evicted"},
+		},
+		{
+			name: "stuck cleanup disabled still enforces retention",
+			spec: func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+				spec.CleanupStuck.Enabled = false
+			},
+			jobs: []batchv1.Job{
+				planJob("running-long", batchv1.JobStatus{Active: 1, StartTime: started(2 * time.Hour)}),
+				planJob("succeeded-old", batchv1.JobStatus{Succeeded: 1, StartTime: started(3 * time.Hour)}),
+				planJob("succeeded-new", batchv1.JobStatus{Succeeded: 1, StartTime: started(time.Hour)}),
+			},
+			stuck:           []string{},
+			excessSucceeded: []string{"succeeded-old"},
+			excessFailed:    []string{},
+		},
+		{
+			name: "ttl deletes jobs within the retention count",
+			spec: func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+				spec.Retain.SuccessfulJobs = 5
+				spec.Retain.FailedJobs = 5
+				spec.Retain.SuccessfulTTL = &metav1.Duration{Duration: 2 * time.Hour}
+				spec.Retain.FailedTTL = &metav1.Duration{Duration: 4 * time.Hour}
+			},
+			jobs: []batchv1.Job{
+				planJob("succeeded-expired", batchv1.JobStatus{Succeeded: 1, StartTime: started(4 * time.Hour), CompletionTime: started(3 * time.Hour)}),
+				planJob("succeeded-fresh", batchv1.JobStatus{Succeeded: 1, StartTime: started(2 * time.Hour), CompletionTime: started(time.Hour)}),
+				planJob("succeeded-unknown", batchv1.JobStatus{Succeeded: 1, StartTime: started(6 * time.Hour)}),
+				planJob("failed-expired", batchv1.JobStatus{Failed: 1, StartTime: started(6 * time.Hour), Conditions: []batchv1.JobCondition{
+					{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, LastTransitionTime: *started(5 * time.Hour)},
+				}}),
+				planJob("failed-fresh", batchv1.JobStatus{Failed: 1, StartTime: started(4 * time.Hour), Conditions: []batchv1.JobCondition{
+					{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, LastTransitionTime: *started(3 * time.Hour)},
+				}}),
+			},
+			stuck:           []string{},
+			excessSucceeded: []string{"succeeded-expired"},
+			excessFailed:    []string{"failed-expired"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cleaner := &lifecyclev1alpha1.CronExecutionCleaner{
+				Spec: lifecyclev1alpha1.CronExecutionCleanerSpec{
+					Namespace:   "default",
+					CronJobName: "my-cronjob",
+					Retain: lifecyclev1alpha1.RetentionPolicy{
+						SuccessfulJobs: 1,
+						FailedJobs:     1,
+					},
+					CleanupStuck: lifecyclev1alpha1.CleanupStuckPolicy{
+						Enabled:    true,
+						StuckAfter: metav1.Duration{Duration: time.Hour},
+					},
+					RunInterval: metav1.Duration{Duration: 5 * time.Minute},
+				},
+			}
+			if tt.spec != nil {
+				tt.spec(&cleaner.Spec)
+			}
+			cleaner.Spec = EffectiveSpec(cleaner)
+
+			plan := planDeletions(cleaner, nil, tt.jobs, now)
+
+			if !sameNames(plan.Stuck, tt.stuck) {
+				t.Fatalf("expected stuck %v, got %v", tt.stuck, jobNames(plan.Stuck))
+			}
+			if !sameNames(plan.ExcessSucceeded, tt.excessSucceeded) {
+				t.Fatalf("expected excess succeeded %v, got %v", tt.excessSucceeded, jobNames(plan.ExcessSucceeded))
+			}
+			if !sameNames(plan.ExcessFailed, tt.excessFailed) {
+				t.Fatalf("expected excess failed %v, got %v", tt.excessFailed, jobNames(plan.ExcessFailed))
+			}
+		})
+	}
+}
+
+func TestImpact(t *testing.T) {
+	now := time.Now()
+	started := func(ago time.Duration) *metav1.Time {
+		return &metav1.Time{Time: now.Add(-ago)}
+	}
+
+	spec := lifecyclev1alpha1.CronExecutionCleanerSpec{
+		Namespace:   "default",
+		CronJobName: "my-cronjob",
+		Retain: lifecyclev1alpha1.RetentionPolicy{
+			SuccessfulJobs: 1,
+			FailedJobs:     1,
+		},
+		CleanupStuck: lifecyclev1alpha1.CleanupStuckPolicy{
+			Enabled:    true,
+			StuckAfter: metav1.Duration{Duration: time.Hour},
+		},
+		RunInterval: metav1.Duration{Duration: 5 * time.Minute},
+	}
+	jobs := []batchv1.Job{
+		planJob("running-long", batchv1.JobStatus{Active: 1, StartTime: started(2 * time.Hour)}),
+		planJob("running-short", batchv1.JobStatus{Active: 1, StartTime: started(10 * time.Minute)}),
+		planJob("succeeded-1", batchv1.JobStatus{Succeeded: 1, StartTime: started(4 * time.Hour)}),
+		planJob("succeeded-2", batchv1.JobStatus{Succeeded: 1, StartTime: started(3 * time.Hour)}),
+		planJob("succeeded-3", batchv1.JobStatus{Succeeded: 1, StartTime: started(time.Hour)}),
+		planJob("failed-1", batchv1.JobStatus{Failed: 1, StartTime: started(3 * time.Hour)}),
+		planJob("failed-2", batchv1.JobStatus{Failed: 1, StartTime: started(time.Hour)}),
+	}
+
+	toDelete, toRetain, byReason := Impact(spec, jobs)
+
+	if toDelete != 4 || toRetain != 3 {
+		t.Fatalf("expected 4 to delete and 3 to retain, got %d and %d", toDelete, toRetain)
+	}
+	if byReason["stuck"] != 1 || byReason["succeeded"] != 2 || byReason["failed"] != 1 {
+		t.Fatalf("unexpected impact by reason: %v", byReason)
+	}
+
+	// The per-run cap limits what a single run deletes
+	spec.MaxDeletionsPerRun = 2
+	toDelete, toRetain, _ = Impact(spec, jobs)
+	if toDelete != 2 || toRetain != 5 {
+		t.Fatalf("expected 2 to delete and 5 to retain with a cap, got %d and %d", toDelete, toRetain)
+	}
+}
+
+func TestPlanDeletionsPerDay(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC)
+	completed := func(name string, at time.Time) batchv1.Job {
+		return planJob(name, batchv1.JobStatus{
+			Succeeded:      1,
+			StartTime:      &metav1.Time{Time: at.Add(-5 * time.Minute)},
+			CompletionTime: &metav1.Time{Time: at},
+		})
+	}
+	day := func(daysAgo, hour int) time.Time {
+		return time.Date(2026, time.March, 10-daysAgo, hour, 0, 0, 0, time.UTC)
+	}
+
+	cleaner := &lifecyclev1alpha1.CronExecutionCleaner{
+		Spec: lifecyclev1alpha1.CronExecutionCleanerSpec{
+			Namespace:   "default",
+			CronJobName: "my-cronjob",
+			Retain: lifecyclev1alpha1.RetentionPolicy{
+				DaysToKeep: 3,
+			},
+			CleanupStuck: lifecyclev1alpha1.CleanupStuckPolicy{
+				Enabled:    true,
+				StuckAfter: metav1.Duration{Duration: time.Hour},
+			},
+			RunInterval: metav1.Duration{Duration: 5 * time.Minute},
+		},
+	}
+	cleaner.Spec = EffectiveSpec(cleaner)
+
+	jobs := []batchv1.Job{
+		completed("today-late", day(0, 10)),
+		completed("today-early", day(0, 8)),
+		completed("yesterday-late", day(1, 20)),
+		completed("yesterday-early", day(1, 9)),
+		completed("two-days-ago", day(2, 15)),
+		completed("four-days-ago", day(4, 12)),
+	}
+
+	plan := planDeletions(cleaner, nil, jobs, now)
+
+	want := []string{"today-early", "yesterday-early", "four-days-ago"}
+	if !sameNames(plan.ExcessSucceeded, want) {
+		t.Fatalf("expected excess succeeded %v, got %v", want, jobNames(plan.ExcessSucceeded))
+	}
+}
+
+func TestPlanDeletionsRetainsPerCronJob(t *testing.T) {
+	now := time.Now()
+	ownedBy := func(name, cronJobName string, startedAgo time.Duration) batchv1.Job {
+		job := planJob(name, batchv1.JobStatus{Succeeded: 1, StartTime: &metav1.Time{Time: now.Add(-startedAgo)}})
+		job.OwnerReferences[0].Name = cronJobName
+		return job
+	}
+	cleaner := &lifecyclev1alpha1.CronExecutionCleaner{
+		Spec: lifecyclev1alpha1.CronExecutionCleanerSpec{
+			Namespace:    "default",
+			CronJobName:  "report-daily",
+			CronJobNames: []string{"report-hourly", "report-daily"},
+			Retain: lifecyclev1alpha1.RetentionPolicy{
+				SuccessfulJobs: 1,
+				FailedJobs:     1,
+			},
+			RunInterval: metav1.Duration{Duration: 5 * time.Minute},
+		},
+	}
+	cleaner.Spec = EffectiveSpec(cleaner)
+
+	jobs := []batchv1.Job{
+		ownedBy("daily-old", "report-daily", 48*time.Hour),
+		ownedBy("daily-new", "report-daily", 24*time.Hour),
+		ownedBy("hourly-old", "report-hourly", 2*time.Hour),
+		ownedBy("hourly-new", "report-hourly", time.Hour),
+		ownedBy("weekly", "report-weekly", 72*time.Hour),
+	}
+
+	plan := planDeletions(cleaner, nil, jobs, now)
+
+	if plan.Owned() != 4 {
+		t.Fatalf("expected the jobs of both CronJobs to be owned, got %d", plan.Owned())
+	}
+	want := []string{"daily-old", "hourly-old"}
+	if !sameNames(plan.ExcessSucceeded, want) {
+		t.Fatalf("expected excess succeeded %v, got %v", want, jobNames(plan.ExcessSucceeded))
+	}
+}
+
+func TestPlanDeletionsAlignsWithCronJobHistoryLimits(t *testing.T) {
+	now := time.Now()
+	completed := func(name string, status batchv1.JobStatus, startedAgo time.Duration) batchv1.Job {
+		status.StartTime = &metav1.Time{Time: now.Add(-startedAgo)}
+		return planJob(name, status)
+	}
+	cleaner := &lifecyclev1alpha1.CronExecutionCleaner{
+		Spec: lifecyclev1alpha1.CronExecutionCleanerSpec{
+			Namespace:   "default",
+			CronJobName: "my-cronjob",
+			Retain: lifecyclev1alpha1.RetentionPolicy{
+				SuccessfulJobs:               3,
+				FailedJobs:                   lifecyclev1alpha1.RetainAll,
+				AlignWithCronJobHistoryLimit: true,
+			},
+			RunInterval: metav1.Duration{Duration: 5 * time.Minute},
+		},
+	}
+	cleaner.Spec = EffectiveSpec(cleaner)
+	cronJobs := map[string]*batchv1.CronJob{
+		"my-cronjob": {
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cronjob", Namespace: "default"},
+			Spec: batchv1.CronJobSpec{
+				SuccessfulJobsHistoryLimit: ptr.To[int32](1),
+				FailedJobsHistoryLimit:     ptr.To[int32](5),
+			},
+		},
+	}
+	jobs := []batchv1.Job{
+		completed("succeeded-1", batchv1.JobStatus{Succeeded: 1}, time.Hour),
+		completed("succeeded-2", batchv1.JobStatus{Succeeded: 1}, 2*time.Hour),
+		completed("succeeded-3", batchv1.JobStatus{Succeeded: 1}, 3*time.Hour),
+		completed("failed-1", batchv1.JobStatus{Failed: 1}, time.Hour),
+		completed("failed-2", batchv1.JobStatus{Failed: 1}, 2*time.Hour),
+		completed("failed-3", batchv1.JobStatus{Failed: 1}, 3*time.Hour),
+		completed("failed-4", batchv1.JobStatus{Failed: 1}, 4*time.Hour),
+		completed("failed-5", batchv1.JobStatus{Failed: 1}, 5*time.Hour),
+		completed("failed-6", batchv1.JobStatus{Failed: 1}, 6*time.Hour),
+	}
+
+	plan := planDeletions(cleaner, cronJobs, jobs, now)
+
+	// The CronJob's smaller successful limit wins over the cleaner's
+	want := []string{"succeeded-2", "succeeded-3"}
+	if !sameNames(plan.ExcessSucceeded, want) {
+		t.Fatalf("expected excess succeeded %v, got %v", want, jobNames(plan.ExcessSucceeded))
+	}
+	// Keeping every failed Job is less strict than the CronJob's limit
+	want = []string{"failed-6"}
+	if !sameNames(plan.ExcessFailed, want) {
+		t.Fatalf("expected excess failed %v, got %v", want, jobNames(plan.ExcessFailed))
+	}
+
+	// Without alignment the cleaner's own counts apply
+	cleaner.Spec.Retain.AlignWithCronJobHistoryLimit = false
+	plan = planDeletions(cleaner, cronJobs, jobs, now)
+	if len(plan.ExcessSucceeded) != 0 || len(plan.ExcessFailed) != 0 {
+		t.Fatalf("expected nothing in excess without alignment, got %v and %v",
+			jobNames(plan.ExcessSucceeded), jobNames(plan.ExcessFailed))
+	}
+}
@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	lifecyclev1alpha1 "github.com/bhatpriyanka8/cron-execution-cleaner/api/v1alpha1"
+)
+
+// postCleanupTimeout bounds a post-cleanup webhook call.
+const postCleanupTimeout = 10 * time.Second
+
+// CleanupSummary is the body POSTed to a cleaner's post-cleanup webhook
+// after a reconcile that deleted Jobs.
+type CleanupSummary struct {
+	// Time of the reconcile
+	Time time.Time `json:"time"`
+
+	// Cleaner that deleted the Jobs, as namespace/name
+	Cleaner string `json:"cleaner"`
+
+	// Number of Jobs deleted, in total and by reason
+	Deleted         int            `json:"deleted"`
+	DeletedByReason map[string]int `json:"deletedByReason"`
+
+	// Deleted Jobs, as namespace/name
+	Jobs []string `json:"jobs"`
+}
+
+// postCleanupHostAllowed reports whether the webhook URL's host is one the
+// operator allowed. An entry without a port allows every port of the host.
+func (r *CronExecutionCleanerReconciler) postCleanupHostAllowed(webhook string) bool {
+	u, err := url.Parse(webhook)
+	if err != nil {
+		return false
+	}
+	for _, host := range r.PostCleanupWebhookHosts {
+		if host == u.Host || host == u.Hostname() {
+			return true
+		}
+	}
+	return false
+}
+
+// notifyPostCleanup POSTs the summary of the deleted Jobs to the cleaner's
+// post-cleanup webhook in the background, so a slow endpoint does not hold
+// up the worker. Webhooks to hosts the operator did not allow are not called.
+// A failed call is logged and otherwise ignored: the Jobs are gone either
+// way.
+func (r *CronExecutionCleanerReconciler) notifyPostCleanup(
+	ctx context.Context,
+	cleaner *lifecyclev1alpha1.CronExecutionCleaner,
+	deleted []batchv1.Job,
+	deletedByReason map[string]int,
+	now time.Time,
+) {
+	logger := ctrl.LoggerFrom(ctx)
+	webhook := cleaner.Spec.PostCleanupWebhook
+	if !r.postCleanupHostAllowed(webhook) {
+		logger.Info("Post-cleanup webhook host is not allowed, not calling it", "url", webhook)
+		r.Recorder.Event(
+			cleaner,
+			corev1.EventTypeWarning,
+			"PostCleanupWebhookNotAllowed",
+			fmt.Sprintf("Host of post-cleanup webhook %s is not allowed by the operator", webhook),
+		)
+		return
+	}
+
+	summary := CleanupSummary{
+		Time:            now,
+		Cleaner:         cleaner.Namespace + "/" + cleaner.Name,
+		Deleted:         len(deleted),
+		DeletedByReason: map[string]int{},
+		Jobs:            make([]string, 0, len(deleted)),
+	}
+	for reason, count := range deletedByReason {
+		if count > 0 {
+			summary.DeletedByReason[reason] = count
+		}
+	}
+	for _, job := range deleted {
+		summary.Jobs = append(summary.Jobs, job.Namespace+"/"+job.Name)
+	}
+
+	// The call outlives the reconcile, but not the timeout
+	callCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), postCleanupTimeout)
+	r.postCleanupCalls.Add(1)
+	go func() {
+		defer r.postCleanupCalls.Done()
+		defer cancel()
+		if err := r.postSummary(callCtx, webhook, summary); err != nil {
+			logger.Error(err, "Post-cleanup webhook failed", "url", webhook)
+			return
+		}
+		logger.V(1).Info("Post-cleanup webhook called", "url", webhook, "deleted", summary.Deleted)
+	}()
+}
+
+func (r *CronExecutionCleanerReconciler) postSummary(ctx context.Context, url string, summary CleanupSummary) error {
+	body, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := r.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
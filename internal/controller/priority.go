@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	lifecyclev1alpha1 "github.com/bhatpriyanka8/cron-execution-cleaner/api/v1alpha1"
+)
+
+// priorityDelayRange is the longest a cleaner whose target is not failing at
+// all is held back before it is queued.
+const priorityDelayRange = 2 * time.Second
+
+// cleanerPriority returns the share of failed Jobs the cleaner saw in its
+// last run, between 0 and 1. Cleaners that have not run yet have priority 0.
+func cleanerPriority(cleaner *lifecyclev1alpha1.CronExecutionCleaner) float64 {
+	ratio, err := strconv.ParseFloat(cleaner.Status.FailureRatio, 64)
+	if err != nil {
+		return 0
+	}
+	return min(max(ratio, 0), 1)
+}
+
+// priorityEnqueuer queues cleaners like handler.EnqueueRequestForObject, but
+// holds each new one back in proportion to how little its target is failing.
+// When many cleaners are queued at once on startup, the initial sync, those
+// with failing targets are therefore reconciled first. Updates, including the
+// controller's own status writes, deletions and requeues requested by
+// Reconcile are queued without delay.
+type priorityEnqueuer struct{}
+
+func (priorityEnqueuer) add(obj client.Object, q workqueue.RateLimitingInterface) {
+	if obj == nil {
+		return
+	}
+	q.Add(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(obj)})
+}
+
+// Create implements handler.EventHandler.
+func (e priorityEnqueuer) Create(_ context.Context, evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	cleaner, ok := evt.Object.(*lifecyclev1alpha1.CronExecutionCleaner)
+	if !ok {
+		return
+	}
+	delay := time.Duration((1 - cleanerPriority(cleaner)) * float64(priorityDelayRange))
+	if delay <= 0 {
+		e.add(cleaner, q)
+		return
+	}
+	q.AddAfter(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cleaner)}, delay)
+}
+
+// Update implements handler.EventHandler.
+func (e priorityEnqueuer) Update(_ context.Context, evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	e.add(evt.ObjectNew, q)
+}
+
+// Delete implements handler.EventHandler.
+func (e priorityEnqueuer) Delete(_ context.Context, evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	e.add(evt.Object, q)
+}
+
+// Generic implements handler.EventHandler.
+func (e priorityEnqueuer) Generic(_ context.Context, evt event.GenericEvent, q workqueue.RateLimitingInterface) {
+	e.add(evt.Object, q)
+}
@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// protectExpressionCostLimit bounds the work a single evaluation of a
+// protection expression may do, so that a costly rule cannot stall a run.
+const protectExpressionCostLimit = 1000000
+
+// protectExpression is a compiled spec.protectExpression.
+type protectExpression struct {
+	program cel.Program
+}
+
+// compileProtectExpression compiles a CEL expression over a Job, bound to the
+// variable object as in a ValidatingAdmissionPolicy. The expression must
+// evaluate to a bool.
+func compileProtectExpression(src string) (protectExpression, error) {
+	env, err := cel.NewEnv(cel.Variable("object", cel.DynType))
+	if err != nil {
+		return protectExpression{}, err
+	}
+	ast, issues := env.Compile(src)
+	if issues != nil && issues.Err() != nil {
+		return protectExpression{}, issues.Err()
+	}
+	if t := ast.OutputType(); !t.IsExactType(cel.BoolType) && !t.IsExactType(cel.DynType) {
+		return protectExpression{}, fmt.Errorf("expression evaluates to %s, not bool", t)
+	}
+	program, err := env.Program(ast, cel.CostLimit(protectExpressionCostLimit))
+	if err != nil {
+		return protectExpression{}, err
+	}
+	return protectExpression{program: program}, nil
+}
+
+// protects reports whether the expression holds for the Job.
+func (e protectExpression) protects(job *batchv1.Job) (bool, error) {
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(job)
+	if err != nil {
+		return false, err
+	}
+	result, _, err := e.program.Eval(map[string]any{"object": object})
+	if err != nil {
+		return false, err
+	}
+	protected, ok := result.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression evaluated to %s, not bool", result.Type())
+	}
+	return protected, nil
+}
+
+// dropProtectedJobs drops the Jobs the expression protects. A Job the
+// expression fails on, e.g. by selecting a field the Job does not have, is
+// kept protected, so that a bad rule never leads to a deletion.
+func dropProtectedJobs(jobs []batchv1.Job, expr protectExpression) []batchv1.Job {
+	kept := []batchv1.Job{}
+	for i := range jobs {
+		if protected, err := expr.protects(&jobs[i]); err != nil || protected {
+			continue
+		}
+		kept = append(kept, jobs[i])
+	}
+	return kept
+}
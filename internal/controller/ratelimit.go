@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	lifecyclev1alpha1 "github.com/bhatpriyanka8/cron-execution-cleaner/api/v1alpha1"
+)
+
+// deletionTokenInterval is how long it takes to earn one deletion token.
+func deletionTokenInterval(limit *lifecyclev1alpha1.DeletionRateLimit) time.Duration {
+	return time.Minute / time.Duration(limit.PerMinute)
+}
+
+// deletionBurst is the most tokens the bucket holds.
+func deletionBurst(limit *lifecyclev1alpha1.DeletionRateLimit) int {
+	if limit.Burst > 0 {
+		return limit.Burst
+	}
+	return limit.PerMinute
+}
+
+// deletionTokenStamp returns t as stored in status. Status times are stored
+// to the second; rounding up never hands out a token early.
+func deletionTokenStamp(t time.Time) *metav1.Time {
+	if truncated := t.Truncate(time.Second); !truncated.Equal(t) {
+		t = truncated.Add(time.Second)
+	}
+	stamp := metav1.NewTime(t)
+	return &stamp
+}
+
+// refillDeletionTokens adds the tokens earned since the last refill to the
+// cleaner's bucket, up to the burst. A new bucket starts full, and the bucket
+// is dropped when the cleaner has no rate limit. A full bucket earns nothing
+// and is left as is, so idle runs do not change the status.
+func refillDeletionTokens(cleaner *lifecyclev1alpha1.CronExecutionCleaner, now time.Time) {
+	limit := cleaner.Spec.DeletionRateLimit
+	status := &cleaner.Status
+	if limit == nil {
+		status.DeletionTokens = 0
+		status.DeletionTokensRefilledAt = nil
+		return
+	}
+
+	burst := deletionBurst(limit)
+	if status.DeletionTokensRefilledAt == nil {
+		status.DeletionTokens = burst
+		status.DeletionTokensRefilledAt = deletionTokenStamp(now)
+		return
+	}
+	if status.DeletionTokens >= burst {
+		status.DeletionTokens = burst
+		return
+	}
+
+	interval := deletionTokenInterval(limit)
+	earned := int(now.Sub(status.DeletionTokensRefilledAt.Time) / interval)
+	if earned <= 0 {
+		return
+	}
+	status.DeletionTokens = min(status.DeletionTokens+earned, burst)
+	// Time towards the next token is only kept while the bucket is not full
+	refilledAt := now
+	if status.DeletionTokens < burst {
+		refilledAt = status.DeletionTokensRefilledAt.Add(time.Duration(earned) * interval)
+	}
+	status.DeletionTokensRefilledAt = deletionTokenStamp(refilledAt)
+}
+
+// deletionTokenLeft reports whether the cleaner's rate limit allows another
+// deletion. Without a rate limit it always does.
+func deletionTokenLeft(cleaner *lifecyclev1alpha1.CronExecutionCleaner) bool {
+	return cleaner.Spec.DeletionRateLimit == nil || cleaner.Status.DeletionTokens > 0
+}
+
+// spendDeletionToken takes a token for a deletion the API server accepted.
+// Failed attempts are not charged. Taking the first token from a full bucket
+// starts the time towards the next one.
+func spendDeletionToken(cleaner *lifecyclev1alpha1.CronExecutionCleaner, now time.Time) {
+	limit := cleaner.Spec.DeletionRateLimit
+	if limit == nil {
+		return
+	}
+	if cleaner.Status.DeletionTokens >= deletionBurst(limit) {
+		cleaner.Status.DeletionTokensRefilledAt = deletionTokenStamp(now)
+	}
+	cleaner.Status.DeletionTokens--
+}
@@ -0,0 +1,3538 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/config"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	lifecyclev1alpha1 "github.com/bhatpriyanka8/cron-execution-cleaner/api/v1alpha1"
+)
+
+const (
+	testNamespace   = "default"
+	testCleanerName = "test-cleaner"
+	testCronJobName = "my-cronjob"
+)
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add client-go scheme: %v", err)
+	}
+	if err := lifecyclev1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add lifecycle scheme: %v", err)
+	}
+	return scheme
+}
+
+func newTestCleaner(mutate func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec)) *lifecyclev1alpha1.CronExecutionCleaner {
+	cleaner := &lifecyclev1alpha1.CronExecutionCleaner{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testCleanerName,
+			Namespace: testNamespace,
+		},
+		Spec: lifecyclev1alpha1.CronExecutionCleanerSpec{
+			Namespace:   testNamespace,
+			CronJobName: testCronJobName,
+			Retain: lifecyclev1alpha1.RetentionPolicy{
+				SuccessfulJobs: 1,
+				FailedJobs:     1,
+			},
+			CleanupStuck: lifecyclev1alpha1.CleanupStuckPolicy{
+				Enabled:    true,
+				StuckAfter: metav1.Duration{Duration: time.Hour},
+			},
+			RunInterval: metav1.Duration{Duration: 5 * time.Minute},
+		},
+	}
+	if mutate != nil {
+		mutate(&cleaner.Spec)
+	}
+	return cleaner
+}
+
+func newOwnedJob(name string, status batchv1.JobStatus) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "batch/v1", Kind: "CronJob", Name: testCronJobName, UID: "cronjob-uid"},
+			},
+		},
+		Status: status,
+	}
+}
+
+func succeededStatus(startedAgo time.Duration) batchv1.JobStatus {
+	return batchv1.JobStatus{
+		Succeeded: 1,
+		StartTime: &metav1.Time{Time: time.Now().Add(-startedAgo)},
+	}
+}
+
+func activeStatus(startedAgo time.Duration) batchv1.JobStatus {
+	return batchv1.JobStatus{
+		Active:    1,
+		StartTime: &metav1.Time{Time: time.Now().Add(-startedAgo)},
+	}
+}
+
+func newJobPod(jobName string, status corev1.PodStatus) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName + "-pod",
+			Namespace: testNamespace,
+			Labels:    map[string]string{"job-name": jobName},
+		},
+		Status: status,
+	}
+}
+
+func newTestReconciler(
+	t *testing.T,
+	funcs interceptor.Funcs,
+	objs ...client.Object,
+) *CronExecutionCleanerReconciler {
+	t.Helper()
+
+	scheme := newTestScheme(t)
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&lifecyclev1alpha1.CronExecutionCleaner{}).
+		WithObjects(objs...).
+		WithInterceptorFuncs(funcs).
+		Build()
+
+	return &CronExecutionCleanerReconciler{
+		Client:   newCountingClient(c),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(100),
+		// Status times are stored with second precision
+		Clock: testingclock.NewFakeClock(time.Now().Truncate(time.Second)),
+	}
+}
+
+// advanceClock moves the reconciler's fake clock forward.
+func advanceClock(r *CronExecutionCleanerReconciler, d time.Duration) {
+	r.Clock.(*testingclock.FakeClock).Step(d)
+}
+
+func reconcileCleaner(t *testing.T, r *CronExecutionCleanerReconciler) (ctrl.Result, error) {
+	t.Helper()
+
+	return r.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: testCleanerName, Namespace: testNamespace},
+	})
+}
+
+func fetchCleaner(t *testing.T, r *CronExecutionCleanerReconciler) *lifecyclev1alpha1.CronExecutionCleaner {
+	t.Helper()
+
+	var cleaner lifecyclev1alpha1.CronExecutionCleaner
+	key := types.NamespacedName{Name: testCleanerName, Namespace: testNamespace}
+	if err := r.Get(context.Background(), key, &cleaner); err != nil {
+		t.Fatalf("failed to fetch cleaner: %v", err)
+	}
+	return &cleaner
+}
+
+func remainingJobs(t *testing.T, r *CronExecutionCleanerReconciler) map[string]bool {
+	t.Helper()
+
+	var jobs batchv1.JobList
+	if err := r.List(context.Background(), &jobs); err != nil {
+		t.Fatalf("failed to list jobs: %v", err)
+	}
+	names := map[string]bool{}
+	for _, job := range jobs.Items {
+		names[job.Name] = true
+	}
+	return names
+}
+
+func counterValue(t *testing.T, counter prometheus.Counter) float64 {
+	t.Helper()
+
+	var metric dto.Metric
+	if err := counter.Write(&metric); err != nil {
+		t.Fatalf("failed to read counter: %v", err)
+	}
+	return metric.GetCounter().GetValue()
+}
+
+func histogramSum(t *testing.T, observer prometheus.Observer) float64 {
+	t.Helper()
+
+	var metric dto.Metric
+	if err := observer.(prometheus.Histogram).Write(&metric); err != nil {
+		t.Fatalf("failed to read histogram: %v", err)
+	}
+	return metric.GetHistogram().GetSampleSum()
+}
+
+func TestReconcilePhase(t *testing.T) {
+	t.Run("normal run", func(t *testing.T) {
+		r := newTestReconciler(t, interceptor.Funcs{},
+			newTestCleaner(nil),
+			newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+			newOwnedJob("job-new", succeededStatus(time.Hour)),
+		)
+
+		if _, err := reconcileCleaner(t, r); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if phase := fetchCleaner(t, r).Status.Phase; phase != lifecyclev1alpha1.PhaseCleaning {
+			t.Fatalf("expected phase Cleaning, got %q", phase)
+		}
+
+		advanceClock(r, 5*time.Minute)
+		if _, err := reconcileCleaner(t, r); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if phase := fetchCleaner(t, r).Status.Phase; phase != lifecyclev1alpha1.PhaseIdle {
+			t.Fatalf("expected phase Idle, got %q", phase)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		r := newTestReconciler(t, interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if _, ok := list.(*batchv1.JobList); ok {
+					return errors.New("list failed")
+				}
+				return c.List(ctx, list, opts...)
+			},
+		}, newTestCleaner(nil))
+
+		if _, err := reconcileCleaner(t, r); err == nil {
+			t.Fatalf("expected error from failing list")
+		}
+		if phase := fetchCleaner(t, r).Status.Phase; phase != lifecyclev1alpha1.PhaseError {
+			t.Fatalf("expected phase Error, got %q", phase)
+		}
+	})
+
+	t.Run("suspend", func(t *testing.T) {
+		r := newTestReconciler(t, interceptor.Funcs{},
+			newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+				spec.Suspend = true
+			}),
+			newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+			newOwnedJob("job-new", succeededStatus(time.Hour)),
+		)
+
+		if _, err := reconcileCleaner(t, r); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if phase := fetchCleaner(t, r).Status.Phase; phase != lifecyclev1alpha1.PhaseSuspended {
+			t.Fatalf("expected phase Suspended, got %q", phase)
+		}
+		if len(remainingJobs(t, r)) != 2 {
+			t.Fatalf("expected no jobs deleted while suspended")
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		r := newTestReconciler(t, interceptor.Funcs{},
+			newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+				spec.RunInterval = metav1.Duration{}
+			}),
+		)
+
+		if _, err := reconcileCleaner(t, r); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if phase := fetchCleaner(t, r).Status.Phase; phase != lifecyclev1alpha1.PhaseInvalid {
+			t.Fatalf("expected phase Invalid, got %q", phase)
+		}
+	})
+
+	t.Run("invalid protect expression", func(t *testing.T) {
+		r := newTestReconciler(t, interceptor.Funcs{},
+			newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+				spec.ProtectExpression = `object.metadata.labels["retain"] ==`
+			}),
+		)
+
+		if _, err := reconcileCleaner(t, r); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if phase := fetchCleaner(t, r).Status.Phase; phase != lifecyclev1alpha1.PhaseInvalid {
+			t.Fatalf("expected phase Invalid, got %q", phase)
+		}
+	})
+
+	t.Run("duplicate weekday override", func(t *testing.T) {
+		r := newTestReconciler(t, interceptor.Funcs{},
+			newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+				spec.Retain.WeekdayOverrides = map[string]int{"Monday": 3, "monday": 5}
+			}),
+		)
+
+		if _, err := reconcileCleaner(t, r); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		cleaner := fetchCleaner(t, r)
+		if cleaner.Status.Phase != lifecyclev1alpha1.PhaseInvalid {
+			t.Fatalf("expected phase Invalid, got %q", cleaner.Status.Phase)
+		}
+		cond := meta.FindStatusCondition(cleaner.Status.Conditions, "Ready")
+		if cond == nil || cond.Reason != "InvalidSpec" || !strings.Contains(cond.Message, `"Monday" and "monday"`) {
+			t.Fatalf("expected InvalidSpec naming both overrides, got %+v", cond)
+		}
+	})
+
+	t.Run("unknown weekday override", func(t *testing.T) {
+		r := newTestReconciler(t, interceptor.Funcs{},
+			newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+				spec.Retain.WeekdayOverrides = map[string]int{"Caturday": 3}
+			}),
+		)
+
+		if _, err := reconcileCleaner(t, r); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if phase := fetchCleaner(t, r).Status.Phase; phase != lifecyclev1alpha1.PhaseInvalid {
+			t.Fatalf("expected phase Invalid, got %q", phase)
+		}
+	})
+}
+
+func TestReconcileMarksJobsBeforeDeletingThem(t *testing.T) {
+	calls := []string{}
+	record := interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			if job, ok := obj.(*batchv1.Job); ok {
+				calls = append(calls, "patch "+job.Name+" "+
+					job.Annotations[lifecyclev1alpha1.DeletedByAnnotation]+" "+
+					job.Annotations[lifecyclev1alpha1.DeletionReasonAnnotation])
+			}
+			return c.Patch(ctx, obj, patch, opts...)
+		},
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			calls = append(calls, "delete "+obj.GetName())
+			return c.Delete(ctx, obj, opts...)
+		},
+	}
+	r := newTestReconciler(t, record,
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.MarkDeletedBy = true
+		}),
+		newOwnedJob("job-new", succeededStatus(time.Minute)),
+		newOwnedJob("job-old", succeededStatus(time.Hour)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{
+		"patch job-old " + testNamespace + "/" + testCleanerName + " succeeded",
+		"delete job-old",
+	}
+	if !slices.Equal(calls, want) {
+		t.Fatalf("expected calls %q, got %q", want, calls)
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		calls = nil
+		r := newTestReconciler(t, record,
+			newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+				spec.MarkDeletedBy = true
+				spec.DryRun = true
+			}),
+			newOwnedJob("job-new", succeededStatus(time.Minute)),
+			newOwnedJob("job-old", succeededStatus(time.Hour)),
+		)
+
+		if _, err := reconcileCleaner(t, r); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(calls) != 0 {
+			t.Fatalf("expected no job writes in dry run, got %q", calls)
+		}
+	})
+}
+
+func TestReconcileDeletesLeftoverMarkedJobsAgain(t *testing.T) {
+	marked := func(name string, requestedAgo time.Duration) *batchv1.Job {
+		job := newOwnedJob(name, succeededStatus(time.Hour))
+		requestedAt := time.Now().Add(-requestedAgo).UTC().Format(time.RFC3339)
+		job.Annotations = map[string]string{
+			lifecyclev1alpha1.DeletedByAnnotation:           testNamespace + "/" + testCleanerName,
+			lifecyclev1alpha1.DeletionReasonAnnotation:      "succeeded",
+			lifecyclev1alpha1.DeletionRequestedAtAnnotation: requestedAt,
+		}
+		return job
+	}
+	deleteAttempts := []string{}
+	r := newTestReconciler(t, interceptor.Funcs{
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			deleteAttempts = append(deleteAttempts, obj.GetName())
+			return c.Delete(ctx, obj, opts...)
+		},
+	},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.Retain.SuccessfulJobs = 5
+		}),
+		marked("job-leftover", time.Hour),
+		marked("job-just-marked", time.Minute),
+		newOwnedJob("job-kept", succeededStatus(time.Hour)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !slices.Equal(deleteAttempts, []string{"job-leftover"}) {
+		t.Fatalf("expected deletion of the leftover job to be attempted again, got %v", deleteAttempts)
+	}
+	if remaining := remainingJobs(t, r); remaining["job-leftover"] || !remaining["job-just-marked"] || !remaining["job-kept"] {
+		t.Fatalf("expected only the leftover job to be deleted, got %v", remaining)
+	}
+}
+
+func TestReconcileSkipsJobsThatChangedSinceListed(t *testing.T) {
+	// The stuck Job succeeds right after the Jobs are listed
+	finishAfterList := interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if err := c.List(ctx, list, opts...); err != nil {
+				return err
+			}
+			if _, ok := list.(*batchv1.JobList); !ok {
+				return nil
+			}
+			var job batchv1.Job
+			if err := c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "job-stuck"}, &job); err != nil {
+				return err
+			}
+			job.Status.Active = 0
+			job.Status.Succeeded = 1
+			return c.Status().Update(ctx, &job)
+		},
+	}
+	r := newTestReconciler(t, finishAfterList,
+		newTestCleaner(nil),
+		newOwnedJob("job-stuck", activeStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Minute)),
+		newOwnedJob("job-old", succeededStatus(time.Hour)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	remaining := remainingJobs(t, r)
+	if !remaining["job-stuck"] {
+		t.Fatalf("expected the job that succeeded since it was listed to be skipped, got %v", remaining)
+	}
+	if remaining["job-old"] {
+		t.Fatalf("expected unchanged excess job to be deleted, got %v", remaining)
+	}
+	if deleted := fetchCleaner(t, r).Status.JobsDeleted; deleted != 1 {
+		t.Fatalf("expected 1 job deleted, got %d", deleted)
+	}
+}
+
+func TestReconcileAppliesWeekdayRetentionOverride(t *testing.T) {
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.Retain.WeekdayOverrides = map[string]int{"Saturday": 3}
+		}),
+		newOwnedJob("job-1", succeededStatus(time.Minute)),
+		newOwnedJob("job-2", succeededStatus(time.Hour)),
+		newOwnedJob("job-3", succeededStatus(2*time.Hour)),
+	)
+	clock := r.Clock.(*testingclock.FakeClock)
+	clock.SetTime(time.Date(2026, time.January, 3, 12, 0, 0, 0, time.UTC)) // Saturday
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if remaining := remainingJobs(t, r); len(remaining) != 3 {
+		t.Fatalf("expected the Saturday count to keep all jobs, got %v", remaining)
+	}
+
+	clock.SetTime(time.Date(2026, time.January, 5, 12, 0, 0, 0, time.UTC)) // Monday
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if remaining := remainingJobs(t, r); len(remaining) != 1 || !remaining["job-1"] {
+		t.Fatalf("expected the default count on Monday, got %v", remaining)
+	}
+}
+
+func TestReconcileCountsForegroundDeletionsOnceGone(t *testing.T) {
+	terminating := newOwnedJob("job-old", succeededStatus(2*time.Hour))
+	terminating.Finalizers = []string{metav1.FinalizerDeleteDependents}
+	terminating.Spec.Template.Spec.Containers = []corev1.Container{{
+		Name: "main",
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+		},
+	}}
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.DeletePropagation = metav1.DeletePropagationForeground
+		}),
+		terminating,
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	status := fetchCleaner(t, r).Status
+	if status.JobsDeleted != 0 || !slices.Equal(status.PendingForegroundDeletions, []string{"job-old"}) {
+		t.Fatalf("expected job-old to be pending and not yet counted, got %d deleted and pending %v",
+			status.JobsDeleted, status.PendingForegroundDeletions)
+	}
+	if status.LastRunTime != nil || len(status.ReclaimedResources) != 0 {
+		t.Fatalf("expected no reclaimed resources or run time yet, got %v at %v",
+			status.ReclaimedResources, status.LastRunTime)
+	}
+
+	// The garbage collector finishes deleting the dependents
+	ctx := context.Background()
+	var job batchv1.Job
+	if err := r.Get(ctx, client.ObjectKeyFromObject(terminating), &job); err != nil {
+		t.Fatalf("failed to get job-old: %v", err)
+	}
+	job.Finalizers = nil
+	if err := r.Update(ctx, &job); err != nil {
+		t.Fatalf("failed to remove finalizer: %v", err)
+	}
+
+	advanceClock(r, 5*time.Minute)
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	status = fetchCleaner(t, r).Status
+	if status.JobsDeleted != 1 || len(status.PendingForegroundDeletions) != 0 {
+		t.Fatalf("expected job-old to be counted once gone, got %d deleted and pending %v",
+			status.JobsDeleted, status.PendingForegroundDeletions)
+	}
+	if cpu := status.ReclaimedResources[corev1.ResourceCPU]; cpu.Cmp(resource.MustParse("500m")) != 0 {
+		t.Fatalf("expected job-old's 500m CPU to be reclaimed once gone, got %v", status.ReclaimedResources)
+	}
+	if status.LastRunTime == nil || !status.LastRunTime.Time.Equal(r.now()) {
+		t.Fatalf("expected the last run time to be set once job-old is gone, got %v", status.LastRunTime)
+	}
+	if len(status.PendingForegroundResources) != 0 {
+		t.Fatalf("expected no pending resources left, got %v", status.PendingForegroundResources)
+	}
+}
+
+func TestReconcileTracksOwnedJobCountDelta(t *testing.T) {
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.Retain.SuccessfulJobs = lifecyclev1alpha1.RetainAll
+		}),
+		newOwnedJob("job-1", succeededStatus(3*time.Hour)),
+		newOwnedJob("job-2", succeededStatus(2*time.Hour)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status := fetchCleaner(t, r).Status; status.OwnedJobCount != 2 || status.OwnedJobCountDelta != 0 {
+		t.Fatalf("expected 2 owned jobs and no delta on the first run, got %d and %d", status.OwnedJobCount, status.OwnedJobCountDelta)
+	}
+
+	for _, name := range []string{"job-3", "job-4", "job-5"} {
+		if err := r.Create(context.Background(), newOwnedJob(name, succeededStatus(time.Hour))); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+	}
+	advanceClock(r, 5*time.Minute)
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status := fetchCleaner(t, r).Status; status.OwnedJobCount != 5 || status.OwnedJobCountDelta != 3 {
+		t.Fatalf("expected 5 owned jobs and a delta of 3, got %d and %d", status.OwnedJobCount, status.OwnedJobCountDelta)
+	}
+}
+
+func TestReconcileCountsDeletionsByHour(t *testing.T) {
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(nil),
+		newOwnedJob("job-oldest", succeededStatus(3*time.Hour)),
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+	r.Clock.(*testingclock.FakeClock).SetTime(time.Date(2026, 1, 7, 14, 30, 0, 0, time.UTC))
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	hourly := fetchCleaner(t, r).Status.HourlyDeletions
+	if len(hourly) != 24 {
+		t.Fatalf("expected 24 hourly buckets, got %v", hourly)
+	}
+	for hour, count := range hourly {
+		want := 0
+		if hour == 14 {
+			want = 2
+		}
+		if count != want {
+			t.Fatalf("expected %d deletions in hour %d, got %d (%v)", want, hour, count, hourly)
+		}
+	}
+}
+
+func TestReconcileWarmup(t *testing.T) {
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.WarmupPeriod = &metav1.Duration{Duration: time.Hour}
+		}),
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cleaner := fetchCleaner(t, r)
+	if cleaner.Status.WarmupStartedAt == nil || cleaner.Status.WarmupComplete {
+		t.Fatalf("expected warm-up to be in progress")
+	}
+	if len(remainingJobs(t, r)) != 2 {
+		t.Fatalf("expected deletions to be deferred during warm-up")
+	}
+
+	advanceClock(r, 2*time.Hour)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !fetchCleaner(t, r).Status.WarmupComplete {
+		t.Fatalf("expected warm-up to be complete")
+	}
+	if remaining := remainingJobs(t, r); len(remaining) != 1 || !remaining["job-new"] {
+		t.Fatalf("expected only job-new to remain after warm-up, got %v", remaining)
+	}
+}
+
+func TestReconcileStatusUpdateFailureStillDeletes(t *testing.T) {
+	r := newTestReconciler(t, interceptor.Funcs{
+		SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+			return errors.New("status subresource unavailable")
+		},
+	},
+		newTestCleaner(nil),
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+	failures := statusUpdateFailures.WithLabelValues(testNamespace, testCleanerName)
+	before := counterValue(t, failures)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("expected status failure to be tolerated, got %v", err)
+	}
+	if remaining := remainingJobs(t, r); len(remaining) != 1 || !remaining["job-new"] {
+		t.Fatalf("expected job-old to be deleted despite status failure, got %v", remaining)
+	}
+	if counterValue(t, failures) <= before {
+		t.Fatalf("expected status update failure to be counted")
+	}
+}
+
+func TestReconcileCountsSkippedJobsByReason(t *testing.T) {
+	fresh := newOwnedJob("job-fresh", succeededStatus(20*time.Minute))
+	fresh.Status.CompletionTime = &metav1.Time{Time: time.Now().Add(-10 * time.Minute)}
+	foreign := newOwnedJob("job-foreign", succeededStatus(4*time.Hour))
+	foreign.OwnerReferences[0].UID = "other-uid"
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.ExcludeNameRegex = "^pinned-"
+			spec.Retain.SuccessfulGrace = &metav1.Duration{Duration: time.Hour}
+		}),
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: testCronJobName, Namespace: testNamespace, UID: "cronjob-uid"}},
+		newOwnedJob("pinned-job", succeededStatus(5*time.Hour)),
+		fresh,
+		foreign,
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-older", succeededStatus(3*time.Hour)),
+	)
+	reasons := []string{"excluded", "grace", "foreign-owner"}
+	before := map[string]float64{}
+	for _, reason := range reasons {
+		before[reason] = counterValue(t, jobsSkipped.WithLabelValues(reason))
+	}
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if remaining := remainingJobs(t, r); remaining["job-older"] || !remaining["job-old"] {
+		t.Fatalf("expected only job-older to be deleted, got %v", remaining)
+	}
+	for _, reason := range reasons {
+		if got := counterValue(t, jobsSkipped.WithLabelValues(reason)) - before[reason]; got != 1 {
+			t.Errorf("expected 1 job skipped as %s, got %v", reason, got)
+		}
+	}
+}
+
+func TestReconcileMarksStarvedCleaner(t *testing.T) {
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.MaxDeletionsPerRun = 1
+			spec.StarvationThreshold = 2
+		}),
+		newOwnedJob("job-1", succeededStatus(4*time.Hour)),
+		newOwnedJob("job-2", succeededStatus(3*time.Hour)),
+		newOwnedJob("job-3", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-4", succeededStatus(time.Hour)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if meta.FindStatusCondition(fetchCleaner(t, r).Status.Conditions, "Starved") != nil {
+		t.Fatalf("expected a single capped run not to mark the cleaner starved")
+	}
+
+	// The CronJob keeps creating Jobs faster than the cap lets them go
+	for _, name := range []string{"job-5", "job-6"} {
+		if err := r.Create(context.Background(), newOwnedJob(name, succeededStatus(time.Minute))); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+	}
+	advanceClock(r, 5*time.Minute)
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cleaner := fetchCleaner(t, r)
+	if !meta.IsStatusConditionTrue(cleaner.Status.Conditions, "Starved") {
+		t.Fatalf("expected the Starved condition after sustained backlog growth, got %+v", cleaner.Status.Conditions)
+	}
+	if cleaner.Status.StarvedRuns != 2 {
+		t.Fatalf("expected 2 starved runs, got %d", cleaner.Status.StarvedRuns)
+	}
+}
+
+func TestReconcileDeletesInCreationOrder(t *testing.T) {
+	created := func(job *batchv1.Job, ago time.Duration) *batchv1.Job {
+		job.CreationTimestamp = metav1.NewTime(time.Now().Add(-ago).Truncate(time.Second))
+		return job
+	}
+	var order []string
+	r := newTestReconciler(t, interceptor.Funcs{
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			order = append(order, obj.GetName())
+			return c.Delete(ctx, obj, opts...)
+		},
+	},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.DeterministicDeletionOrder = true
+		}),
+		created(newOwnedJob("job-a", succeededStatus(2*time.Hour)), 10*time.Hour),
+		created(newOwnedJob("job-b", succeededStatus(3*time.Hour)), 5*time.Hour),
+		created(newOwnedJob("job-c", succeededStatus(4*time.Hour)), 8*time.Hour),
+		created(newOwnedJob("job-new", succeededStatus(time.Hour)), time.Hour),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"job-a", "job-c", "job-b"}; !slices.Equal(order, want) {
+		t.Fatalf("expected deletions oldest-created first %v, got %v", want, order)
+	}
+}
+
+func TestReconcileTreatsAlreadyDeletedJobsAsDone(t *testing.T) {
+	// Another cleaner sharing the target deletes job-old between this
+	// cleaner's list and its delete
+	r := newTestReconciler(t, interceptor.Funcs{
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			if obj.GetName() == "job-old" {
+				if err := c.Delete(ctx, obj); err != nil {
+					return err
+				}
+			}
+			return c.Delete(ctx, obj, opts...)
+		},
+	},
+		newTestCleaner(nil),
+		newOwnedJob("job-oldest", succeededStatus(3*time.Hour)),
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+	sink := &fakeAuditSink{}
+	r.AuditSink = sink
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("expected NotFound on delete to be tolerated, got %v", err)
+	}
+
+	if remaining := remainingJobs(t, r); len(remaining) != 1 || !remaining["job-new"] {
+		t.Fatalf("expected only job-new to remain, got %v", remaining)
+	}
+	cleaner := fetchCleaner(t, r)
+	if cleaner.Status.JobsDeleted != 1 {
+		t.Fatalf("expected only the job this cleaner deleted to be counted, got %d", cleaner.Status.JobsDeleted)
+	}
+	if len(sink.records) != 1 || sink.records[0].Object != testNamespace+"/job-oldest" {
+		t.Fatalf("expected only job-oldest to be audited, got %+v", sink.records)
+	}
+	if !meta.IsStatusConditionTrue(cleaner.Status.Conditions, "Ready") {
+		t.Fatalf("expected the cleaner to stay ready")
+	}
+}
+
+func TestReconcileFallsBackWithoutStatusSubresource(t *testing.T) {
+	subresourceUpdates := 0
+	var plainUpdates []*lifecyclev1alpha1.CronExecutionCleaner
+	r := newTestReconciler(t, interceptor.Funcs{
+		SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+			subresourceUpdates++
+			return apierrors.NewNotFound(lifecyclev1alpha1.GroupVersion.WithResource("cronexecutioncleaners/status").GroupResource(), obj.GetName())
+		},
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			if cleaner, ok := obj.(*lifecyclev1alpha1.CronExecutionCleaner); ok {
+				plainUpdates = append(plainUpdates, cleaner.DeepCopy())
+			}
+			return c.Update(ctx, obj, opts...)
+		},
+	},
+		newTestCleaner(nil),
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if subresourceUpdates != 1 || len(plainUpdates) != 1 {
+		t.Fatalf("expected one status subresource attempt and one plain update, got %d and %d",
+			subresourceUpdates, len(plainUpdates))
+	}
+	written := plainUpdates[0]
+	if written.Status.JobsDeleted != 1 {
+		t.Fatalf("expected the plain update to carry the status, got %+v", written.Status)
+	}
+	if written.Spec.CleanupStuck.Action != "" || len(written.Spec.OwnerKinds) != 0 {
+		t.Fatalf("expected the plain update not to persist the defaulted spec, got %+v", written.Spec)
+	}
+
+	advanceClock(r, 5*time.Minute)
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if subresourceUpdates != 1 || len(plainUpdates) != 2 {
+		t.Fatalf("expected the fallback to stick, got %d subresource attempts and %d plain updates",
+			subresourceUpdates, len(plainUpdates))
+	}
+}
+
+func TestAggregatedMetricsOmitObjectLabels(t *testing.T) {
+	setAggregateMetrics(true)
+	defer setAggregateMetrics(false)
+
+	r := newTestReconciler(t, interceptor.Funcs{
+		SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+			return errors.New("status subresource unavailable")
+		},
+	},
+		newTestCleaner(nil),
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+	)
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("expected status failure to be tolerated, got %v", err)
+	}
+
+	families, err := metrics.Registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	var found bool
+	for _, family := range families {
+		if family.GetName() != "cron_cleaner_status_update_failures_total" {
+			continue
+		}
+		found = true
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "namespace" || label.GetName() == "name" {
+					t.Fatalf("expected no per-object labels in aggregated mode, got %s=%q", label.GetName(), label.GetValue())
+				}
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("expected the status update failure to be counted")
+	}
+}
+
+func TestReconcileStuckRequiresPodProgressStall(t *testing.T) {
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.CleanupStuck.RequirePodProgressStall = true
+		}),
+		newOwnedJob("job-initializing", activeStatus(2*time.Hour)),
+		newJobPod("job-initializing", corev1.PodStatus{
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodInitialized, Status: corev1.ConditionFalse},
+			},
+			InitContainerStatuses: []corev1.ContainerStatus{
+				{Name: "init", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+			},
+		}),
+		newOwnedJob("job-stalled", activeStatus(2*time.Hour)),
+		newJobPod("job-stalled", corev1.PodStatus{
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodInitialized, Status: corev1.ConditionTrue},
+			},
+		}),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	remaining := remainingJobs(t, r)
+	if !remaining["job-initializing"] {
+		t.Fatalf("expected job with initializing pod not to be flagged as stuck")
+	}
+	if remaining["job-stalled"] {
+		t.Fatalf("expected stalled job to be deleted")
+	}
+}
+
+func TestReconcileExcludesJobsWithinStartupProbe(t *testing.T) {
+	probing := func(jobName string, startedAgo time.Duration) *corev1.Pod {
+		pod := newJobPod(jobName, corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:    "main",
+				Started: ptr.To(false),
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{
+					StartedAt: metav1.NewTime(time.Now().Add(-startedAgo)),
+				}},
+			}},
+		})
+		pod.Spec.Containers = []corev1.Container{{
+			Name: "main",
+			StartupProbe: &corev1.Probe{
+				PeriodSeconds:    60,
+				FailureThreshold: 30,
+			},
+		}}
+		return pod
+	}
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.CleanupStuck.ExcludeWithinStartupProbe = true
+		}),
+		newOwnedJob("job-probing", activeStatus(2*time.Hour)),
+		probing("job-probing", 10*time.Minute),
+		newOwnedJob("job-probe-exhausted", activeStatus(2*time.Hour)),
+		probing("job-probe-exhausted", 2*time.Hour),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	remaining := remainingJobs(t, r)
+	if !remaining["job-probing"] {
+		t.Fatalf("expected job within its startup probe budget to be excluded from stuck deletion")
+	}
+	if remaining["job-probe-exhausted"] {
+		t.Fatalf("expected job past its startup probe budget to be deleted")
+	}
+}
+
+func TestReconcileAnnotatesTargetCronJob(t *testing.T) {
+	annotate := func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+		spec.AnnotateTargetCronJob = true
+	}
+
+	t.Run("records the last cleanup", func(t *testing.T) {
+		cronJob := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: testCronJobName, Namespace: testNamespace}}
+		r := newTestReconciler(t, interceptor.Funcs{},
+			newTestCleaner(annotate),
+			cronJob,
+			newOwnedJob("job-new", succeededStatus(time.Minute)),
+			newOwnedJob("job-old", succeededStatus(time.Hour)),
+		)
+
+		if _, err := reconcileCleaner(t, r); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := r.Get(context.Background(), client.ObjectKeyFromObject(cronJob), cronJob); err != nil {
+			t.Fatalf("failed to get CronJob: %v", err)
+		}
+		var summary cleanupSummary
+		if err := json.Unmarshal([]byte(cronJob.Annotations[lifecyclev1alpha1.LastCleanupAnnotation]), &summary); err != nil {
+			t.Fatalf("failed to decode annotation %q: %v", cronJob.Annotations[lifecyclev1alpha1.LastCleanupAnnotation], err)
+		}
+		if summary.Cleaner != testCleanerName || summary.Deleted != 1 || summary.ByReason["succeeded"] != 1 {
+			t.Fatalf("unexpected summary %+v", summary)
+		}
+		if !summary.Time.Time.Equal(r.now()) {
+			t.Fatalf("expected cleanup time %s, got %s", r.now(), summary.Time.Time)
+		}
+	})
+
+	t.Run("missing CronJob", func(t *testing.T) {
+		r := newTestReconciler(t, interceptor.Funcs{},
+			newTestCleaner(annotate),
+			newOwnedJob("job-new", succeededStatus(time.Minute)),
+			newOwnedJob("job-old", succeededStatus(time.Hour)),
+		)
+
+		if _, err := reconcileCleaner(t, r); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if remaining := remainingJobs(t, r); remaining["job-old"] {
+			t.Fatalf("expected cleanup to go ahead without the CronJob, got %v", remaining)
+		}
+	})
+}
+
+func TestReconcileRequeuesWhenNextJobBecomesEligible(t *testing.T) {
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.RequeueOnEligibility = true
+			spec.Retain.SuccessfulGrace = &metav1.Duration{Duration: 2 * time.Minute}
+		}),
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: testCronJobName, Namespace: testNamespace}},
+	)
+	now := r.now()
+	finished := func(name string, startedAgo, finishedAgo time.Duration) *batchv1.Job {
+		return newOwnedJob(name, batchv1.JobStatus{
+			Succeeded:      1,
+			StartTime:      &metav1.Time{Time: now.Add(-startedAgo)},
+			CompletionTime: &metav1.Time{Time: now.Add(-finishedAgo)},
+		})
+	}
+	for _, job := range []*batchv1.Job{
+		finished("job-old", 10*time.Minute, 9*time.Minute),
+		// Leaves its grace period in 30s, pushing job-old out of retention
+		finished("job-recent", 2*time.Minute, 90*time.Second),
+	} {
+		if err := r.Create(context.Background(), job); err != nil {
+			t.Fatalf("failed to create %s: %v", job.Name, err)
+		}
+	}
+
+	result, err := reconcileCleaner(t, r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RequeueAfter != 30*time.Second {
+		t.Fatalf("expected requeue after 30s, got %s", result.RequeueAfter)
+	}
+	if remaining := remainingJobs(t, r); len(remaining) != 2 {
+		t.Fatalf("expected no deletions yet, got %v", remaining)
+	}
+
+	advanceClock(r, 30*time.Second)
+	result, err = reconcileCleaner(t, r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if remaining := remainingJobs(t, r); remaining["job-old"] || !remaining["job-recent"] {
+		t.Fatalf("expected job-old to be deleted once job-recent became eligible, got %v", remaining)
+	}
+	if result.RequeueAfter != 5*time.Minute {
+		t.Fatalf("expected requeue after the run interval once nothing is pending, got %s", result.RequeueAfter)
+	}
+}
+
+func TestReconcileEstimatesDrainTime(t *testing.T) {
+	objs := []client.Object{newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+		spec.MaxDeletionsPerRun = 2
+	})}
+	for i := 0; i < 8; i++ {
+		objs = append(objs, newOwnedJob(fmt.Sprintf("job-%d", i), succeededStatus(time.Duration(i+1)*time.Hour)))
+	}
+	r := newTestReconciler(t, interceptor.Funcs{}, objs...)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 7 excess jobs, 2 deleted now, the other 5 take 3 more runs
+	drainAt := fetchCleaner(t, r).Status.EstimatedDrainTime
+	if want := r.now().Add(15 * time.Minute); drainAt == nil || !drainAt.Time.Equal(want) {
+		t.Fatalf("expected drain estimate %s, got %v", want, drainAt)
+	}
+
+	for i := 0; i < 3; i++ {
+		advanceClock(r, 5*time.Minute)
+		if _, err := reconcileCleaner(t, r); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if remaining := remainingJobs(t, r); len(remaining) != 1 {
+		t.Fatalf("expected the backlog to be drained by the estimate, got %v", remaining)
+	}
+	if drainAt := fetchCleaner(t, r).Status.EstimatedDrainTime; drainAt != nil {
+		t.Fatalf("expected no drain estimate once the backlog is gone, got %v", drainAt)
+	}
+}
+
+func TestReconcileSummarizesRunInStatusMessage(t *testing.T) {
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.MaxDeletionsPerRun = 2
+		}),
+		newOwnedJob("job-stuck", activeStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Minute)),
+		newOwnedJob("job-old", succeededStatus(time.Hour)),
+		newOwnedJob("job-older", succeededStatus(2*time.Hour)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	at := r.now().UTC().Format("2006-01-02T15:04Z07:00")
+	want := "Deleted 1 succeeded, 1 stuck; skipped 1 at " + at
+	if got := fetchCleaner(t, r).Status.Message; got != want {
+		t.Fatalf("expected message %q, got %q", want, got)
+	}
+
+	advanceClock(r, 5*time.Minute)
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	advanceClock(r, 5*time.Minute)
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = "Nothing to delete at " + r.now().UTC().Format("2006-01-02T15:04Z07:00")
+	if got := fetchCleaner(t, r).Status.Message; got != want {
+		t.Fatalf("expected message %q, got %q", want, got)
+	}
+}
+
+func TestReconcileWaitsForAllCompletionsOfParallelJobs(t *testing.T) {
+	parallel := newOwnedJob("job-parallel", batchv1.JobStatus{
+		Succeeded: 2,
+		StartTime: &metav1.Time{Time: time.Now().Add(-30 * time.Minute)},
+	})
+	parallel.Spec.Completions = ptr.To[int32](3)
+	parallel.Spec.Parallelism = ptr.To[int32](3)
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(nil),
+		parallel,
+		newOwnedJob("job-new", succeededStatus(time.Minute)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if remaining := remainingJobs(t, r); !remaining["job-parallel"] {
+		t.Fatalf("expected the partially complete job to be kept, got %v", remaining)
+	}
+
+	var job batchv1.Job
+	if err := r.Get(context.Background(), client.ObjectKeyFromObject(parallel), &job); err != nil {
+		t.Fatalf("failed to get job: %v", err)
+	}
+	job.Status.Succeeded = 3
+	if err := r.Status().Update(context.Background(), &job); err != nil {
+		t.Fatalf("failed to complete job: %v", err)
+	}
+	advanceClock(r, 10*time.Minute)
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if remaining := remainingJobs(t, r); remaining["job-parallel"] || !remaining["job-new"] {
+		t.Fatalf("expected the completed job to be cleaned up, got %v", remaining)
+	}
+}
+
+func TestReconcilePausesAllCleanersFromConfigMap(t *testing.T) {
+	pause := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "cleaner-system", Name: "cleaner-pause"},
+		Data:       map[string]string{"paused": "true"},
+	}
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(nil),
+		pause,
+		newOwnedJob("job-new", succeededStatus(time.Minute)),
+		newOwnedJob("job-old", succeededStatus(time.Hour)),
+	)
+	r.PauseConfigMap = client.ObjectKeyFromObject(pause)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if remaining := remainingJobs(t, r); len(remaining) != 2 {
+		t.Fatalf("expected no deletions while paused, got %v", remaining)
+	}
+	cleaner := fetchCleaner(t, r)
+	if !meta.IsStatusConditionTrue(cleaner.Status.Conditions, "GloballyPaused") {
+		t.Fatalf("expected GloballyPaused condition, got %+v", cleaner.Status.Conditions)
+	}
+
+	// Changing the ConfigMap queues every cleaner, other ConfigMaps are ignored
+	if requests := r.enqueueAllCleaners(context.Background(), pause); len(requests) != 1 ||
+		requests[0].NamespacedName != (types.NamespacedName{Namespace: testNamespace, Name: testCleanerName}) {
+		t.Fatalf("expected the cleaner to be queued, got %v", requests)
+	}
+	other := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "cleaner-system", Name: "unrelated"}}
+	if requests := r.enqueueAllCleaners(context.Background(), other); len(requests) != 0 {
+		t.Fatalf("expected unrelated ConfigMaps to be ignored, got %v", requests)
+	}
+
+	pause.Data["paused"] = "false"
+	if err := r.Update(context.Background(), pause); err != nil {
+		t.Fatalf("failed to resume: %v", err)
+	}
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if remaining := remainingJobs(t, r); remaining["job-old"] || !remaining["job-new"] {
+		t.Fatalf("expected cleanup to resume, got %v", remaining)
+	}
+	cleaner = fetchCleaner(t, r)
+	if meta.FindStatusCondition(cleaner.Status.Conditions, "GloballyPaused") != nil {
+		t.Fatalf("expected GloballyPaused condition to be cleared, got %+v", cleaner.Status.Conditions)
+	}
+}
+
+func TestReconcileExemptsJobsWithinStartingDeadline(t *testing.T) {
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: testCronJobName, Namespace: testNamespace},
+		Spec:       batchv1.CronJobSpec{StartingDeadlineSeconds: ptr.To[int64](2 * 60 * 60)},
+	}
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.CleanupStuck.RespectStartingDeadline = true
+		}),
+		cronJob,
+		newOwnedJob("job-recent", activeStatus(90*time.Minute)),
+		newOwnedJob("job-long", activeStatus(3*time.Hour)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cleaner := fetchCleaner(t, r)
+	if !slices.Equal(cleaner.Status.StuckJobNames, []string{"job-long"}) {
+		t.Fatalf("expected only job-long to be flagged stuck, got %v", cleaner.Status.StuckJobNames)
+	}
+	if remaining := remainingJobs(t, r); !remaining["job-recent"] || remaining["job-long"] {
+		t.Fatalf("expected the job within the starting deadline to be kept, got %v", remaining)
+	}
+}
+
+func TestReconcileFlagsJobsWithUnschedulablePods(t *testing.T) {
+	unschedulable := func(jobName string, since time.Duration) *corev1.Pod {
+		return newJobPod(jobName, corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{{
+				Type:               corev1.PodScheduled,
+				Status:             corev1.ConditionFalse,
+				Reason:             corev1.PodReasonUnschedulable,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-since)),
+			}},
+		})
+	}
+	// The Job controller has not recorded a start time for these Jobs
+	notStarted := batchv1.JobStatus{Active: 1}
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.CleanupStuck.IncludeUnschedulable = true
+		}),
+		newOwnedJob("job-unschedulable", notStarted),
+		unschedulable("job-unschedulable", 2*time.Hour),
+		newOwnedJob("job-pending-briefly", notStarted),
+		unschedulable("job-pending-briefly", 10*time.Minute),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cleaner := fetchCleaner(t, r)
+	if !slices.Equal(cleaner.Status.StuckJobNames, []string{"job-unschedulable"}) {
+		t.Fatalf("expected only job-unschedulable to be flagged stuck, got %v", cleaner.Status.StuckJobNames)
+	}
+	remaining := remainingJobs(t, r)
+	if remaining["job-unschedulable"] || !remaining["job-pending-briefly"] {
+		t.Fatalf("expected only the long-unschedulable job to be deleted, got %v", remaining)
+	}
+}
+
+func TestReconcileHandlesAbandonedJobsImmediately(t *testing.T) {
+	initializing := func(jobName string) *corev1.Pod {
+		return newJobPod(jobName, corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{
+				{Name: "init", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+			},
+		})
+	}
+
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.CleanupStuck.RequirePodProgressStall = true
+			spec.CleanupStuck.MaxAge = &metav1.Duration{Duration: 24 * time.Hour}
+			spec.CleanupStuck.MaxAgeAction = lifecyclev1alpha1.StuckActionQuarantine
+		}),
+		newOwnedJob("job-abandoned", activeStatus(72*time.Hour)),
+		initializing("job-abandoned"),
+		newOwnedJob("job-initializing", activeStatus(2*time.Hour)),
+		initializing("job-initializing"),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if names := fetchCleaner(t, r).Status.StuckJobNames; !slices.Equal(names, []string{"job-abandoned"}) {
+		t.Fatalf("expected only the abandoned job to be stuck, got %v", names)
+	}
+	var abandoned batchv1.Job
+	if err := r.Get(context.Background(), types.NamespacedName{Name: "job-abandoned", Namespace: testNamespace}, &abandoned); err != nil {
+		t.Fatalf("expected abandoned job to be kept for quarantine: %v", err)
+	}
+	if abandoned.Labels[lifecyclev1alpha1.DefaultQuarantineLabel] != "true" {
+		t.Fatalf("expected abandoned job to be quarantined despite its pod still starting")
+	}
+}
+
+func TestReconcileSkipsListWhenNothingDue(t *testing.T) {
+	jobLists := 0
+	r := newTestReconciler(t, interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if _, ok := list.(*batchv1.JobList); ok {
+				jobLists++
+			}
+			return c.List(ctx, list, opts...)
+		},
+	}, newTestCleaner(nil))
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if jobLists != 1 {
+		t.Fatalf("expected 1 job list on first reconcile, got %d", jobLists)
+	}
+
+	// Nothing changed and the run interval has not elapsed
+	advanceClock(r, time.Minute)
+	result, err := reconcileCleaner(t, r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if jobLists != 1 {
+		t.Fatalf("expected job list to be skipped, got %d lists", jobLists)
+	}
+	if result.RequeueAfter != 4*time.Minute {
+		t.Fatalf("expected requeue for the remaining 4m, got %s", result.RequeueAfter)
+	}
+
+	// A spec change forces a fresh evaluation
+	cleaner := fetchCleaner(t, r)
+	cleaner.Generation++
+	if err := r.Update(context.Background(), cleaner); err != nil {
+		t.Fatalf("failed to update cleaner: %v", err)
+	}
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if jobLists != 2 {
+		t.Fatalf("expected job list after spec change, got %d lists", jobLists)
+	}
+}
+
+// queueTestManager provides what controller.NewUnmanaged needs from a
+// manager, so controllers can be run against the fake client.
+type queueTestManager struct {
+	manager.Manager
+}
+
+func (queueTestManager) GetLogger() logr.Logger { return logr.Discard() }
+
+func (queueTestManager) GetControllerOptions() config.Controller { return config.Controller{} }
+
+// startPartitionControllers runs a single-worker controller per partition,
+// wired like SetupWithManager, and returns the channel each one watches.
+func startPartitionControllers(ctx context.Context, t *testing.T, r *CronExecutionCleanerReconciler) []chan event.GenericEvent {
+	t.Helper()
+
+	var sources []chan event.GenericEvent
+	for _, p := range r.partitions() {
+		c, err := controller.NewUnmanaged(p.name, queueTestManager{}, controller.Options{
+			Reconciler:              partitionReconciler{CronExecutionCleanerReconciler: r, partition: p},
+			MaxConcurrentReconciles: 1,
+		})
+		if err != nil {
+			t.Fatalf("failed to create controller: %v", err)
+		}
+		ch := make(chan event.GenericEvent, 10)
+		if err := c.Watch(&source.Channel{Source: ch}, &handler.EnqueueRequestForObject{}, r.partitionPredicate(p)); err != nil {
+			t.Fatalf("failed to watch: %v", err)
+		}
+		go func() {
+			_ = c.Start(ctx)
+		}()
+		sources = append(sources, ch)
+	}
+	return sources
+}
+
+func TestReconcileSmallCleanerNotQueuedBehindLargeOne(t *testing.T) {
+	release := make(chan struct{})
+	inFlight := make(chan struct{})
+
+	large := newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+		spec.Namespace = "large"
+	})
+	large.Name = "large-cleaner"
+	large.Status.OwnedJobCount = 500
+	small := newTestCleaner(nil)
+	small.Status.OwnedJobCount = 3
+
+	r := newTestReconciler(t, interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			listOpts := &client.ListOptions{}
+			listOpts.ApplyOptions(opts)
+			if _, ok := list.(*batchv1.JobList); ok && listOpts.Namespace == "large" {
+				close(inFlight)
+				<-release
+			}
+			return c.List(ctx, list, opts...)
+		},
+	}, small, large)
+	r.LargeCleanerJobs = 100
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	defer close(release)
+	sources := startPartitionControllers(ctx, t, r)
+	if len(sources) != 2 {
+		t.Fatalf("expected 2 partitions, got %d", len(sources))
+	}
+
+	// Both cleaners are offered to every controller, large one first
+	for _, ch := range sources {
+		ch <- event.GenericEvent{Object: large.DeepCopy()}
+		ch <- event.GenericEvent{Object: small.DeepCopy()}
+	}
+	select {
+	case <-inFlight:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("large cleaner was not reconciled")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for fetchCleaner(t, r).Status.LastEvaluatedTime == nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("small cleaner was queued behind the large one")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestPartitionsWithoutLargeCleanerJobs(t *testing.T) {
+	r := &CronExecutionCleanerReconciler{}
+	partitions := r.partitions()
+	if len(partitions) != 1 {
+		t.Fatalf("expected a single partition, got %v", partitions)
+	}
+	cleaner := newTestCleaner(nil)
+	cleaner.Status.OwnedJobCount = 1000
+	if !r.partitionPredicate(partitions[0]).Generic(event.GenericEvent{Object: cleaner}) {
+		t.Fatalf("expected the single partition to admit every cleaner")
+	}
+}
+
+func TestReconcileRequeuesAtMaintenanceWindowBoundaries(t *testing.T) {
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.RunInterval = metav1.Duration{Duration: 2 * time.Hour}
+		}),
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: testCronJobName, Namespace: testNamespace}},
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+	// The window opens 30 minutes from now, on a minute boundary, for an hour
+	r.Clock = testingclock.NewFakeClock(r.now().Truncate(time.Minute))
+	cleaner := fetchCleaner(t, r)
+	cleaner.Spec.MaintenanceWindow = &lifecyclev1alpha1.MaintenanceWindow{
+		Start:    r.now().Add(30 * time.Minute).UTC().Format("15:04"),
+		Duration: metav1.Duration{Duration: time.Hour},
+	}
+	if err := r.Update(context.Background(), cleaner); err != nil {
+		t.Fatalf("failed to update cleaner: %v", err)
+	}
+
+	// Before the window: nothing is deleted until it opens
+	result, err := reconcileCleaner(t, r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RequeueAfter != 30*time.Minute {
+		t.Fatalf("expected a requeue when the window opens in 30m, got %s", result.RequeueAfter)
+	}
+	if len(remainingJobs(t, r)) != 2 {
+		t.Fatalf("expected no deletions outside the window")
+	}
+	if phase := fetchCleaner(t, r).Status.Phase; phase != lifecyclev1alpha1.PhaseWaiting {
+		t.Fatalf("expected phase %s, got %s", lifecyclev1alpha1.PhaseWaiting, phase)
+	}
+
+	// In the window: clean up and come back when it closes, sooner than the
+	// run interval
+	advanceClock(r, result.RequeueAfter)
+	result, err = reconcileCleaner(t, r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RequeueAfter != time.Hour {
+		t.Fatalf("expected a requeue when the window closes in 1h, got %s", result.RequeueAfter)
+	}
+	if remaining := remainingJobs(t, r); len(remaining) != 1 || !remaining["job-new"] {
+		t.Fatalf("expected the excess job to be deleted in the window, got %v", remaining)
+	}
+
+	// After the window: wait for it to open again the next day
+	advanceClock(r, result.RequeueAfter)
+	result, err = reconcileCleaner(t, r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RequeueAfter != 23*time.Hour {
+		t.Fatalf("expected a requeue when the window opens again in 23h, got %s", result.RequeueAfter)
+	}
+}
+
+func TestReconcileNeverDeletesJobWithActivePods(t *testing.T) {
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.CleanupStuck.StuckAfter = metav1.Duration{Duration: 24 * time.Hour}
+		}),
+		newOwnedJob("job-still-running", batchv1.JobStatus{
+			Active:    1,
+			Succeeded: 1,
+			StartTime: &metav1.Time{Time: time.Now().Add(-3 * time.Hour)},
+		}),
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	remaining := remainingJobs(t, r)
+	if !remaining["job-still-running"] {
+		t.Fatalf("expected job with active pods not to be deleted")
+	}
+	if remaining["job-old"] {
+		t.Fatalf("expected job-old to be deleted as excess")
+	}
+}
+
+func TestReconcileReadyDebounce(t *testing.T) {
+	failing := false
+	r := newTestReconciler(t, interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if _, ok := list.(*batchv1.JobList); ok && failing {
+				return errors.New("list failed")
+			}
+			return c.List(ctx, list, opts...)
+		},
+	}, newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+		spec.ReadyFailureThreshold = 3
+	}))
+
+	readyStatus := func() metav1.ConditionStatus {
+		t.Helper()
+		condition := meta.FindStatusCondition(fetchCleaner(t, r).Status.Conditions, "Ready")
+		if condition == nil {
+			t.Fatalf("expected Ready condition to be set")
+		}
+		return condition.Status
+	}
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if readyStatus() != metav1.ConditionTrue {
+		t.Fatalf("expected Ready to be True after a successful run")
+	}
+
+	// A single transient failure keeps Ready True
+	failing = true
+	advanceClock(r, 5*time.Minute)
+	if _, err := reconcileCleaner(t, r); err == nil {
+		t.Fatalf("expected error from failing list")
+	}
+	if readyStatus() != metav1.ConditionTrue {
+		t.Fatalf("expected Ready to stay True after a single failure")
+	}
+
+	failing = false
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if failures := fetchCleaner(t, r).Status.ConsecutiveFailures; failures != 0 {
+		t.Fatalf("expected failure streak to reset, got %d", failures)
+	}
+
+	// Sustained failures flip Ready to False
+	failing = true
+	for i := 1; i <= 3; i++ {
+		advanceClock(r, 5*time.Minute)
+		if _, err := reconcileCleaner(t, r); err == nil {
+			t.Fatalf("expected error from failing list")
+		}
+		want := metav1.ConditionTrue
+		if i == 3 {
+			want = metav1.ConditionFalse
+		}
+		if got := readyStatus(); got != want {
+			t.Fatalf("after %d failures expected Ready %s, got %s", i, want, got)
+		}
+	}
+}
+
+func TestReconcileFastRetention(t *testing.T) {
+	fastRetain := 0
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        testCronJobName,
+			Namespace:   testNamespace,
+			Annotations: map[string]string{lifecyclev1alpha1.FastCleanupAnnotation: "true"},
+		},
+	}
+
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.Retain.FastRetain = &fastRetain
+		}),
+		cronJob,
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if remaining := remainingJobs(t, r); len(remaining) != 0 {
+		t.Fatalf("expected fast retention to delete all jobs, got %v", remaining)
+	}
+	if retain := fetchCleaner(t, r).Spec.Retain.SuccessfulJobs; retain != 1 {
+		t.Fatalf("expected stored spec to be left untouched, got successfulJobs=%d", retain)
+	}
+}
+
+func TestReconcileCountsAPICalls(t *testing.T) {
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(nil),
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+
+	before := map[string]float64{}
+	for _, verb := range apiVerbs {
+		before[verb] = histogramSum(t, apiCallsPerReconcile.WithLabelValues(verb))
+	}
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Get the cleaner and its CronJob, list its jobs, re-read and delete the
+	// excess one, write status
+	want := map[string]float64{"get": 3, "list": 1, "create": 0, "update": 1, "patch": 0, "delete": 1}
+	for _, verb := range apiVerbs {
+		got := histogramSum(t, apiCallsPerReconcile.WithLabelValues(verb)) - before[verb]
+		if got != want[verb] {
+			t.Fatalf("expected %v %s calls, got %v", want[verb], verb, got)
+		}
+	}
+}
+
+func TestReconcileRespectsPVCReferences(t *testing.T) {
+	pod := newJobPod("job-with-pvc", corev1.PodStatus{Phase: corev1.PodSucceeded})
+	pod.Spec.Volumes = []corev1.Volume{{
+		Name: "data",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "shared-data"},
+		},
+	}}
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared-data", Namespace: testNamespace},
+		Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+	}
+
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.RespectPVCReferences = true
+		}),
+		newOwnedJob("job-with-pvc", succeededStatus(3*time.Hour)),
+		pod,
+		pvc,
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	remaining := remainingJobs(t, r)
+	if !remaining["job-with-pvc"] {
+		t.Fatalf("expected job holding a bound PVC to be skipped")
+	}
+	if remaining["job-old"] {
+		t.Fatalf("expected job without PVC references to be deleted")
+	}
+}
+
+func TestReconcileDefersJobsAwaitingVolumeDetach(t *testing.T) {
+	podWithVolume := func(jobName, claim string) *corev1.Pod {
+		pod := newJobPod(jobName, corev1.PodStatus{Phase: corev1.PodSucceeded})
+		pod.Spec.NodeName = "node-1"
+		pod.Spec.Volumes = []corev1.Volume{{
+			Name: "data",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim},
+			},
+		}}
+		return pod
+	}
+	claim := func(name, volume string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: volume},
+		}
+	}
+	attachment := func(volume string, attached bool) *storagev1.VolumeAttachment {
+		return &storagev1.VolumeAttachment{
+			ObjectMeta: metav1.ObjectMeta{Name: "attachment-" + volume},
+			Spec: storagev1.VolumeAttachmentSpec{
+				Attacher: "csi.example.com",
+				NodeName: "node-1",
+				Source:   storagev1.VolumeAttachmentSource{PersistentVolumeName: ptr.To(volume)},
+			},
+			Status: storagev1.VolumeAttachmentStatus{Attached: attached},
+		}
+	}
+
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.RespectVolumeDetach = true
+		}),
+		newOwnedJob("job-attached", succeededStatus(3*time.Hour)),
+		podWithVolume("job-attached", "data-attached"),
+		claim("data-attached", "pv-attached"),
+		attachment("pv-attached", true),
+		newOwnedJob("job-detached", succeededStatus(2*time.Hour)),
+		podWithVolume("job-detached", "data-detached"),
+		claim("data-detached", "pv-detached"),
+		attachment("pv-detached", false),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	remaining := remainingJobs(t, r)
+	if !remaining["job-attached"] {
+		t.Fatalf("expected job whose pod volume is still attached to be deferred")
+	}
+	if remaining["job-detached"] {
+		t.Fatalf("expected job whose pod volume is detached to be deleted")
+	}
+}
+
+func TestReconcileDefersJobsWithUnshippedLogs(t *testing.T) {
+	shipped := newJobPod("job-shipped", corev1.PodStatus{Phase: corev1.PodSucceeded})
+	shipped.Annotations = map[string]string{"logs-shipped": "true"}
+
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.Retain.RequireLogsShippedAnnotation = "logs-shipped"
+		}),
+		newOwnedJob("job-unshipped", succeededStatus(3*time.Hour)),
+		newJobPod("job-unshipped", corev1.PodStatus{Phase: corev1.PodSucceeded}),
+		newOwnedJob("job-shipped", succeededStatus(2*time.Hour)),
+		shipped,
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	remaining := remainingJobs(t, r)
+	if !remaining["job-unshipped"] {
+		t.Fatalf("expected job whose pod lacks the logs-shipped annotation to be deferred")
+	}
+	if remaining["job-shipped"] {
+		t.Fatalf("expected job whose logs were shipped to be deleted")
+	}
+}
+
+func TestReconcileStuckReportOnly(t *testing.T) {
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.CleanupStuck.ReportOnly = true
+		}),
+		newOwnedJob("job-stuck", activeStatus(2*time.Hour)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !remainingJobs(t, r)["job-stuck"] {
+		t.Fatalf("expected stuck job not to be deleted in report-only mode")
+	}
+	if stuck := fetchCleaner(t, r).Status.StuckJobs; stuck != 1 {
+		t.Fatalf("expected 1 stuck job in status, got %d", stuck)
+	}
+
+	events := r.Recorder.(*record.FakeRecorder).Events
+	select {
+	case event := <-events:
+		if !strings.Contains(event, "StuckJobsDetected") || !strings.Contains(event, "job-stuck") {
+			t.Fatalf("unexpected event %q", event)
+		}
+	default:
+		t.Fatalf("expected a StuckJobsDetected event")
+	}
+}
+
+func TestReconcileReportsStuckJobsInStatus(t *testing.T) {
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(nil),
+		newOwnedJob("job-stuck", activeStatus(2*time.Hour)),
+		newOwnedJob("job-running", activeStatus(10*time.Minute)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	status := fetchCleaner(t, r).Status
+	if status.StuckJobs != 1 {
+		t.Fatalf("expected 1 stuck job, got %d", status.StuckJobs)
+	}
+	if len(status.StuckJobNames) != 1 || status.StuckJobNames[0] != "job-stuck" {
+		t.Fatalf("expected stuck job names [job-stuck], got %v", status.StuckJobNames)
+	}
+}
+
+func TestReconcileSelectsJobsByJobTemplateLabels(t *testing.T) {
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: testCronJobName, Namespace: testNamespace},
+		Spec: batchv1.CronJobSpec{
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "nightly-report"}},
+			},
+		},
+	}
+	labeledJob := func(name string, status batchv1.JobStatus) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testNamespace,
+				Labels:    map[string]string{"app": "nightly-report"},
+			},
+			Status: status,
+		}
+	}
+	unrelated := newOwnedJob("job-unlabeled", succeededStatus(5*time.Hour))
+	unrelated.OwnerReferences = nil
+
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.UseJobTemplateLabels = true
+		}),
+		cronJob,
+		labeledJob("job-old", succeededStatus(2*time.Hour)),
+		labeledJob("job-new", succeededStatus(time.Hour)),
+		unrelated,
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	remaining := remainingJobs(t, r)
+	if remaining["job-old"] || !remaining["job-new"] {
+		t.Fatalf("expected retention to apply to labeled jobs, got %v", remaining)
+	}
+	if !remaining["job-unlabeled"] {
+		t.Fatalf("expected job without template labels to be left alone")
+	}
+}
+
+func TestReconcileSkipsUnchangedStatusUpdate(t *testing.T) {
+	statusUpdates := 0
+	r := newTestReconciler(t, interceptor.Funcs{
+		SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+			statusUpdates++
+			return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+		},
+	},
+		newTestCleaner(nil),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if statusUpdates != 1 {
+		t.Fatalf("expected first reconcile to write status, got %d writes", statusUpdates)
+	}
+
+	advanceClock(r, 5*time.Minute)
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if statusUpdates != 1 {
+		t.Fatalf("expected no status write when nothing changed, got %d writes", statusUpdates)
+	}
+}
+
+func TestReconcileSkipsJobsAlreadyTerminating(t *testing.T) {
+	terminating := newOwnedJob("job-terminating", succeededStatus(3*time.Hour))
+	terminating.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	terminating.Finalizers = []string{"example.com/hold"}
+	var deletes []string
+	r := newTestReconciler(t, interceptor.Funcs{
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			deletes = append(deletes, obj.GetName())
+			return c.Delete(ctx, obj, opts...)
+		},
+	},
+		newTestCleaner(nil),
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: testCronJobName, Namespace: testNamespace}},
+		terminating,
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !slices.Equal(deletes, []string{"job-old"}) {
+		t.Fatalf("expected only job-old to be deleted, got %v", deletes)
+	}
+	cleaner := fetchCleaner(t, r)
+	if cleaner.Status.AlreadyTerminating != 1 {
+		t.Fatalf("expected 1 job counted as already terminating, got %d", cleaner.Status.AlreadyTerminating)
+	}
+	if cleaner.Status.OwnedJobCount != 2 {
+		t.Fatalf("expected the terminating job not to be counted as owned, got %d", cleaner.Status.OwnedJobCount)
+	}
+}
+
+func TestReconcileSendsResults(t *testing.T) {
+	results := make(chan ReconcileResult, 1)
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(nil),
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: testCronJobName, Namespace: testNamespace}},
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+	r.Results = results
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	select {
+	case result := <-results:
+		want := types.NamespacedName{Name: testCleanerName, Namespace: testNamespace}
+		if result.Cleaner != want || result.Deleted != 1 || result.DeletedByReason["succeeded"] != 1 || result.Err != nil {
+			t.Fatalf("unexpected result %+v", result)
+		}
+	default:
+		t.Fatalf("expected a result to be sent")
+	}
+
+	// A consumer that falls behind loses results instead of stalling reconciles
+	results <- ReconcileResult{}
+	done := make(chan error)
+	go func() {
+		_, err := reconcileCleaner(t, r)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("reconcile blocked on a full results channel")
+	}
+	if len(results) != 1 || (<-results).Cleaner.Name != "" {
+		t.Fatalf("expected the result to be dropped while the channel was full")
+	}
+}
+
+func TestReconcileRejectsInvalidSpec(t *testing.T) {
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.Retain.SuccessfulJobs = -2
+		}),
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: testCronJobName, Namespace: testNamespace}},
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+		newOwnedJob("job-stuck", activeStatus(2*time.Hour)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if remaining := remainingJobs(t, r); len(remaining) != 3 {
+		t.Fatalf("expected no jobs to be deleted for an invalid spec, got %v", remaining)
+	}
+	cleaner := fetchCleaner(t, r)
+	ready := meta.FindStatusCondition(cleaner.Status.Conditions, "Ready")
+	if ready == nil || ready.Status != metav1.ConditionFalse || ready.Reason != "InvalidSpec" {
+		t.Fatalf("expected Ready=False with reason InvalidSpec, got %+v", ready)
+	}
+	if cleaner.Status.Phase != lifecyclev1alpha1.PhaseInvalid {
+		t.Fatalf("expected phase %s, got %s", lifecyclev1alpha1.PhaseInvalid, cleaner.Status.Phase)
+	}
+}
+
+func TestReconcileReadOnlyNeverMutates(t *testing.T) {
+	for _, action := range []lifecyclev1alpha1.StuckAction{
+		lifecyclev1alpha1.StuckActionDelete,
+		lifecyclev1alpha1.StuckActionQuarantine,
+	} {
+		t.Run(string(action), func(t *testing.T) {
+			var mutations []string
+			record := func(verb string, obj client.Object) {
+				if _, ok := obj.(*lifecyclev1alpha1.CronExecutionCleaner); !ok {
+					mutations = append(mutations, fmt.Sprintf("%s %T %s", verb, obj, obj.GetName()))
+				}
+			}
+			ttl := int32(3600)
+			withTTL := newOwnedJob("job-ttl", succeededStatus(3*time.Hour))
+			withTTL.Spec.TTLSecondsAfterFinished = &ttl
+			r := newTestReconciler(t, interceptor.Funcs{
+				Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+					record("delete", obj)
+					return c.Delete(ctx, obj, opts...)
+				},
+				Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+					record("update", obj)
+					return c.Update(ctx, obj, opts...)
+				},
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					record("patch", obj)
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			},
+				newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+					spec.ReadOnly = true
+					spec.CleanupStuck.Action = action
+					spec.AdoptTTLJobs = true
+					spec.MarkDeletedBy = true
+					spec.AnnotateTargetCronJob = true
+					spec.CleanupAssociatedServices = true
+				}),
+				&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: testCronJobName, Namespace: testNamespace}},
+				withTTL,
+				newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+				newOwnedJob("job-new", succeededStatus(time.Hour)),
+				newOwnedJob("job-stuck", activeStatus(2*time.Hour)),
+			)
+
+			if _, err := reconcileCleaner(t, r); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(mutations) > 0 {
+				t.Fatalf("expected no mutating calls in read-only mode, got %v", mutations)
+			}
+			if remaining := remainingJobs(t, r); len(remaining) != 4 {
+				t.Fatalf("expected every job to remain, got %v", remaining)
+			}
+			if stuck := fetchCleaner(t, r).Status.StuckJobs; stuck != 1 {
+				t.Fatalf("expected the stuck job to still be reported, got %d", stuck)
+			}
+		})
+	}
+}
+
+func TestReconcileEnforcesRetentionWithStuckCleanupDisabled(t *testing.T) {
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.CleanupStuck.Enabled = false
+			spec.Retain.SuccessfulJobs = 1
+		}),
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: testCronJobName, Namespace: testNamespace}},
+		newOwnedJob("job-oldest", succeededStatus(3*time.Hour)),
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+		newOwnedJob("job-running", activeStatus(2*time.Hour)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	remaining := remainingJobs(t, r)
+	if len(remaining) != 2 || !remaining["job-new"] || !remaining["job-running"] {
+		t.Fatalf("expected job-new and the running job to remain, got %v", remaining)
+	}
+}
+
+func TestReconcileRaisesRunIntervalToControllerMinimum(t *testing.T) {
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.RunInterval = metav1.Duration{Duration: 30 * time.Second}
+		}),
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: testCronJobName, Namespace: testNamespace}},
+	)
+	r.MinRunInterval = 10 * time.Minute
+
+	result, err := reconcileCleaner(t, r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RequeueAfter != 10*time.Minute {
+		t.Fatalf("expected requeue after the 10m minimum, got %v", result.RequeueAfter)
+	}
+	cleaner := fetchCleaner(t, r)
+	if cleaner.Spec.RunInterval.Duration != 30*time.Second {
+		t.Fatalf("expected stored runInterval to be left alone, got %v", cleaner.Spec.RunInterval.Duration)
+	}
+	clamped := meta.FindStatusCondition(cleaner.Status.Conditions, "RunIntervalClamped")
+	if clamped == nil || clamped.Status != metav1.ConditionTrue || clamped.Reason != "BelowMinimum" {
+		t.Fatalf("expected RunIntervalClamped condition, got %+v", clamped)
+	}
+
+	// A second run before the minimum has passed is deferred
+	advanceClock(r, time.Minute)
+	if result, err := reconcileCleaner(t, r); err != nil || result.RequeueAfter != 9*time.Minute {
+		t.Fatalf("expected the next run to wait out the minimum, got %v, %v", result.RequeueAfter, err)
+	}
+}
+
+func TestReconcileFencesDeletionsPerNamespace(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	slowDelete := interceptor.Funcs{
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			if _, ok := obj.(*batchv1.Job); ok {
+				n := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			return c.Delete(ctx, obj, opts...)
+		},
+	}
+
+	other := newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+		spec.CronJobName = "other-cronjob"
+	})
+	other.Name = "other-cleaner"
+	objs := []client.Object{
+		newTestCleaner(nil), other,
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: testCronJobName, Namespace: testNamespace}},
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "other-cronjob", Namespace: testNamespace}},
+	}
+	for i := 0; i < 4; i++ {
+		objs = append(objs, newOwnedJob(fmt.Sprintf("job-%d", i), succeededStatus(time.Duration(i+1)*time.Hour)))
+		otherJob := newOwnedJob(fmt.Sprintf("other-job-%d", i), succeededStatus(time.Duration(i+1)*time.Hour))
+		otherJob.OwnerReferences[0].Name = "other-cronjob"
+		objs = append(objs, otherJob)
+	}
+	r := newTestReconciler(t, slowDelete, objs...)
+	r.NamespaceFence = NewNamespaceFence(1)
+
+	var wg sync.WaitGroup
+	for _, name := range []string{testCleanerName, "other-cleaner"} {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			_, err := r.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: name, Namespace: testNamespace},
+			})
+			if err != nil {
+				t.Errorf("unexpected error reconciling %s: %v", name, err)
+			}
+		}(name)
+	}
+	wg.Wait()
+
+	if got := len(remainingJobs(t, r)); got != 2 {
+		t.Fatalf("expected 2 jobs to remain, got %d", got)
+	}
+	if got := peak.Load(); got != 1 {
+		t.Fatalf("expected at most 1 deletion in flight, saw %d", got)
+	}
+}
+
+func TestReconcileMarksDegradedWhileAPIServerUnreachable(t *testing.T) {
+	unreachable := true
+	refuse := interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if _, ok := list.(*batchv1.JobList); ok && unreachable {
+				return &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+			}
+			return c.List(ctx, list, opts...)
+		},
+	}
+	r := newTestReconciler(t, refuse, newTestCleaner(nil))
+
+	for i := 1; i <= apiUnavailableThreshold; i++ {
+		if _, err := reconcileCleaner(t, r); err == nil {
+			t.Fatalf("expected the list error to be returned")
+		}
+		degraded := meta.FindStatusCondition(fetchCleaner(t, r).Status.Conditions, "Degraded")
+		if i < apiUnavailableThreshold && degraded != nil {
+			t.Fatalf("expected no Degraded condition after %d failed runs, got %+v", i, degraded)
+		}
+		if i == apiUnavailableThreshold && (degraded == nil || degraded.Status != metav1.ConditionTrue ||
+			degraded.Reason != "APIUnavailable") {
+			t.Fatalf("expected Degraded condition with reason APIUnavailable, got %+v", degraded)
+		}
+	}
+
+	unreachable = false
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if degraded := meta.FindStatusCondition(fetchCleaner(t, r).Status.Conditions, "Degraded"); degraded != nil {
+		t.Fatalf("expected Degraded condition to be cleared on recovery, got %+v", degraded)
+	}
+}
+
+func TestReconcileResultIsCoherent(t *testing.T) {
+	failJobList := interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if _, ok := list.(*batchv1.JobList); ok {
+				return errors.New("list failed")
+			}
+			return c.List(ctx, list, opts...)
+		},
+	}
+	failCleanerGet := interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if _, ok := obj.(*lifecyclev1alpha1.CronExecutionCleaner); ok {
+				return errors.New("get failed")
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+	}
+
+	tests := []struct {
+		name      string
+		funcs     interceptor.Funcs
+		mutate    func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec)
+		reconcile int
+		noTarget  bool
+		wantErr   bool
+		requeue   time.Duration
+	}{
+		{name: "success", reconcile: 1, requeue: 5 * time.Minute},
+		{name: "target missing", reconcile: 1, noTarget: true, requeue: 20 * time.Minute},
+		{name: "target missing with custom requeue", reconcile: 1, noTarget: true, requeue: time.Hour,
+			mutate: func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+				spec.MissingTargetRequeue = &metav1.Duration{Duration: time.Hour}
+			}},
+		{name: "nothing due", reconcile: 2, requeue: 5 * time.Minute},
+		{name: "suspended", reconcile: 1, requeue: 5 * time.Minute,
+			mutate: func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) { spec.Suspend = true }},
+		{name: "invalid spec", reconcile: 1, requeue: defaultRequeueInterval,
+			mutate: func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) { spec.RunInterval = metav1.Duration{} }},
+		{name: "list failure", reconcile: 1, funcs: failJobList, wantErr: true},
+		{name: "get failure", reconcile: 1, funcs: failCleanerGet, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objs := []client.Object{newTestCleaner(tt.mutate)}
+			if !tt.noTarget {
+				objs = append(objs, &batchv1.CronJob{
+					ObjectMeta: metav1.ObjectMeta{Name: testCronJobName, Namespace: testNamespace},
+				})
+			}
+			r := newTestReconciler(t, tt.funcs, objs...)
+
+			var result ctrl.Result
+			var err error
+			for i := 0; i < tt.reconcile; i++ {
+				result, err = reconcileCleaner(t, r)
+			}
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				if result != (ctrl.Result{}) {
+					t.Fatalf("expected an empty result alongside the error, got %+v", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.RequeueAfter != tt.requeue {
+				t.Fatalf("expected requeue after %s, got %s", tt.requeue, result.RequeueAfter)
+			}
+		})
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		r := newTestReconciler(t, interceptor.Funcs{})
+
+		result, err := reconcileCleaner(t, r)
+		if err != nil || result != (ctrl.Result{}) {
+			t.Fatalf("expected no requeue for a deleted cleaner, got %+v, %v", result, err)
+		}
+	})
+}
+
+func TestObjectMetricsEndpoint(t *testing.T) {
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(nil),
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+	r.ObjectMetrics = NewObjectMetrics()
+	// A second cleaner waits for a maintenance window that opens in an hour
+	waiting := newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+		spec.MaintenanceWindow = &lifecyclev1alpha1.MaintenanceWindow{
+			Start:    r.now().Add(time.Hour).UTC().Format("15:04"),
+			Duration: metav1.Duration{Duration: 30 * time.Minute},
+		}
+	})
+	waiting.Name = "waiting-cleaner"
+	if err := r.Create(context.Background(), waiting); err != nil {
+		t.Fatalf("failed to create cleaner: %v", err)
+	}
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(waiting)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	server := httptest.NewServer(r.ObjectMetrics.Handler())
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	req.Header.Set("Accept", "application/openmetrics-text; version=1.0.0")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to scrape endpoint: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read response: %v", err)
+	}
+
+	if contentType := resp.Header.Get("Content-Type"); !strings.HasPrefix(contentType, "application/openmetrics-text") {
+		t.Fatalf("expected OpenMetrics content type, got %q", contentType)
+	}
+	for _, series := range []string{
+		`cron_cleaner_object_jobs_deleted_total{name="test-cleaner",namespace="default"} 1.0`,
+		`cron_cleaner_object_phase{name="test-cleaner",namespace="default",phase="Cleaning"} 1.0`,
+		`cron_cleaner_object_phase{name="test-cleaner",namespace="default",phase="Idle"} 0.0`,
+		`cron_cleaner_object_phase{name="waiting-cleaner",namespace="default",phase="Waiting"} 1.0`,
+		"# EOF",
+	} {
+		if !strings.Contains(string(body), series) {
+			t.Fatalf("expected exposition to contain %q, got:\n%s", series, body)
+		}
+	}
+}
+
+func TestImpactMatchesCappedReconcile(t *testing.T) {
+	mutate := func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+		spec.MaxDeletionsPerRun = 2
+		spec.CleanupStuck.MaxAge = &metav1.Duration{Duration: 4 * time.Hour}
+		spec.CleanupStuck.MaxAgeAction = lifecyclev1alpha1.StuckActionDelete
+		spec.CleanupStuck.Action = lifecyclev1alpha1.StuckActionQuarantine
+		spec.CleanupStuck.QuarantineLabel = "sre.example.com/quarantine"
+	}
+	jobs := []*batchv1.Job{
+		newOwnedJob("job-abandoned", activeStatus(5*time.Hour)),
+		newOwnedJob("job-stuck", activeStatus(2*time.Hour)),
+		newOwnedJob("job-oldest", succeededStatus(4*time.Hour)),
+		newOwnedJob("job-old", succeededStatus(3*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	}
+	cleaner := newTestCleaner(mutate)
+	objs := []client.Object{cleaner, &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: testCronJobName, Namespace: testNamespace}}}
+	var items []batchv1.Job
+	for _, job := range jobs {
+		objs = append(objs, job)
+		items = append(items, *job.DeepCopy())
+	}
+	r := newTestReconciler(t, interceptor.Funcs{}, objs...)
+
+	toDelete, _, byReason := Impact(cleaner.Spec, items)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	remaining := remainingJobs(t, r)
+	if deleted := len(jobs) - len(remaining); deleted != toDelete {
+		t.Fatalf("Impact predicted %d deletions, the reconcile deleted %d", toDelete, deleted)
+	}
+	// The abandoned Job takes the budget first, the stuck one is only
+	// quarantined, and one excess succeeded Job fits in what is left
+	if remaining["job-abandoned"] || !remaining["job-stuck"] || remaining["job-oldest"] == remaining["job-old"] {
+		t.Fatalf("unexpected remaining jobs %v", remaining)
+	}
+	if byReason["stuck"] != 1 || byReason["succeeded"] != 1 {
+		t.Fatalf("unexpected impact by reason %v", byReason)
+	}
+
+	// Read-only and dry-run cleaners delete nothing
+	for _, mode := range []func(*lifecyclev1alpha1.CronExecutionCleanerSpec){
+		func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) { spec.ReadOnly = true },
+		func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) { spec.DryRun = true },
+	} {
+		spec := cleaner.Spec.DeepCopy()
+		mode(spec)
+		if toDelete, toRetain, _ := Impact(*spec, items); toDelete != 0 || toRetain != len(items) {
+			t.Fatalf("expected no deletions, got %d to delete and %d to retain", toDelete, toRetain)
+		}
+	}
+}
+
+func TestReconcileQuarantinesStuckJobs(t *testing.T) {
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.CleanupStuck.Action = lifecyclev1alpha1.StuckActionQuarantine
+			spec.CleanupStuck.QuarantineLabel = "sre.example.com/quarantine"
+		}),
+		newOwnedJob("job-stuck", activeStatus(2*time.Hour)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var job batchv1.Job
+	key := types.NamespacedName{Name: "job-stuck", Namespace: testNamespace}
+	if err := r.Get(context.Background(), key, &job); err != nil {
+		t.Fatalf("expected quarantined job to still exist: %v", err)
+	}
+	if job.Labels["sre.example.com/quarantine"] != "true" {
+		t.Fatalf("expected job to carry the quarantine label, got %v", job.Labels)
+	}
+
+	// Quarantined jobs are no longer treated as stuck
+	advanceClock(r, 5*time.Minute)
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stuck := fetchCleaner(t, r).Status.StuckJobs; stuck != 0 {
+		t.Fatalf("expected quarantined job to be excluded from stuck processing, got %d stuck", stuck)
+	}
+}
+
+func TestReconcileRecoversFromPanic(t *testing.T) {
+	r := newTestReconciler(t, interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if _, ok := list.(*batchv1.JobList); ok {
+				panic("malformed job")
+			}
+			return c.List(ctx, list, opts...)
+		},
+	}, newTestCleaner(nil))
+
+	result, err := reconcileCleaner(t, r)
+	if err == nil || !strings.Contains(err.Error(), "malformed job") {
+		t.Fatalf("expected panic to be returned as an error, got %v", err)
+	}
+	if result != (ctrl.Result{}) {
+		t.Fatalf("expected an empty result alongside the error, got %+v", result)
+	}
+
+	condition := meta.FindStatusCondition(fetchCleaner(t, r).Status.Conditions, "ReconcilePanic")
+	if condition == nil || condition.Status != metav1.ConditionTrue {
+		t.Fatalf("expected ReconcilePanic condition to be recorded, got %+v", condition)
+	}
+}
+
+func TestReconcileMirrorsTargetLastScheduleTime(t *testing.T) {
+	lastSchedule := metav1.NewTime(time.Now().Add(-10 * time.Minute).Truncate(time.Second))
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: testCronJobName, Namespace: testNamespace},
+		Status:     batchv1.CronJobStatus{LastScheduleTime: &lastSchedule},
+	}
+
+	r := newTestReconciler(t, interceptor.Funcs{}, newTestCleaner(nil), cronJob)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := fetchCleaner(t, r).Status.TargetLastScheduleTime
+	if got == nil || !got.Equal(&lastSchedule) {
+		t.Fatalf("expected targetLastScheduleTime %v, got %v", lastSchedule, got)
+	}
+}
+
+func TestReconcileAdoptsTTLJobs(t *testing.T) {
+	ttl := int32(600)
+	job := newOwnedJob("job-with-ttl", succeededStatus(time.Hour))
+	job.Spec.TTLSecondsAfterFinished = &ttl
+
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.AdoptTTLJobs = true
+		}),
+		job,
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var adopted batchv1.Job
+	if err := r.Get(context.Background(), client.ObjectKeyFromObject(job), &adopted); err != nil {
+		t.Fatalf("failed to get job: %v", err)
+	}
+	if adopted.Spec.TTLSecondsAfterFinished != nil {
+		t.Fatalf("expected TTL to be removed, got %d", *adopted.Spec.TTLSecondsAfterFinished)
+	}
+}
+
+func TestReconcileDryRunDoesNotAdoptTTLJobs(t *testing.T) {
+	for name, mutate := range map[string]func(*lifecyclev1alpha1.CronExecutionCleanerSpec){
+		"dryRun":          func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) { spec.DryRun = true },
+		"dryRunRetention": func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) { spec.DryRunRetention = true },
+	} {
+		t.Run(name, func(t *testing.T) {
+			ttl := int32(600)
+			jobs := []*batchv1.Job{
+				newOwnedJob("job-active", activeStatus(time.Minute)),
+				newOwnedJob("job-succeeded", succeededStatus(time.Hour)),
+				newOwnedJob("job-failed", batchv1.JobStatus{
+					Failed:    1,
+					StartTime: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+				}),
+			}
+			objs := []client.Object{newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+				spec.AdoptTTLJobs = true
+				mutate(spec)
+			})}
+			for _, job := range jobs {
+				job.Spec.TTLSecondsAfterFinished = &ttl
+				objs = append(objs, job)
+			}
+			r := newTestReconciler(t, interceptor.Funcs{}, objs...)
+
+			versions := map[string]string{}
+			for _, job := range jobs {
+				var before batchv1.Job
+				if err := r.Get(context.Background(), client.ObjectKeyFromObject(job), &before); err != nil {
+					t.Fatalf("failed to get job: %v", err)
+				}
+				versions[job.Name] = before.ResourceVersion
+			}
+
+			if _, err := reconcileCleaner(t, r); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, job := range jobs {
+				var after batchv1.Job
+				if err := r.Get(context.Background(), client.ObjectKeyFromObject(job), &after); err != nil {
+					t.Fatalf("failed to get job: %v", err)
+				}
+				if after.ResourceVersion != versions[job.Name] {
+					t.Fatalf("expected job %s to be unchanged in a dry run", job.Name)
+				}
+				if after.Spec.TTLSecondsAfterFinished == nil || *after.Spec.TTLSecondsAfterFinished != ttl {
+					t.Fatalf("expected job %s to keep its TTL in a dry run", job.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileTracesPhases(t *testing.T) {
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(nil),
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+	recorder := tracetest.NewSpanRecorder()
+	r.TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	// Spans are children of the span the reconcile was called with
+	ctx, parent := r.TracerProvider.Tracer("test").Start(context.Background(), "reconcile")
+	_, err := r.Reconcile(ctx, ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: testCleanerName, Namespace: testNamespace},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parent.End()
+
+	var ended []string
+	for _, span := range recorder.Ended() {
+		if span.Name() == "reconcile" {
+			continue
+		}
+		ended = append(ended, span.Name())
+		if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("expected span %s to be a child of the reconcile span", span.Name())
+		}
+	}
+	want := []string{spanList, spanClassify, spanPlan, spanDelete}
+	if !slices.Equal(ended, want) {
+		t.Fatalf("expected spans %v, got %v", want, ended)
+	}
+}
+
+func TestReconcileWithoutTracerProviderRecordsNoSpans(t *testing.T) {
+	r := newTestReconciler(t, interceptor.Funcs{}, newTestCleaner(nil))
+
+	_, span := r.startSpan(context.Background(), spanList)
+	defer span.End()
+	if span.IsRecording() || span.SpanContext().IsValid() {
+		t.Fatalf("expected a no-op span without a tracer provider")
+	}
+}
+
+func TestReconcileDeletesAssociatedServices(t *testing.T) {
+	service := func(name, jobName string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testNamespace,
+				Labels:    map[string]string{"job-name": jobName},
+			},
+		}
+	}
+
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.CleanupAssociatedServices = true
+		}),
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		service("job-old-workers", "job-old"),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+		service("job-new-workers", "job-new"),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var services corev1.ServiceList
+	if err := r.List(context.Background(), &services, client.InNamespace(testNamespace)); err != nil {
+		t.Fatalf("failed to list services: %v", err)
+	}
+	if len(services.Items) != 1 || services.Items[0].Name != "job-new-workers" {
+		t.Fatalf("expected only the retained job's service to remain, got %d services", len(services.Items))
+	}
+	if deleted := fetchCleaner(t, r).Status.ServicesDeleted; deleted != 1 {
+		t.Fatalf("expected servicesDeleted=1, got %d", deleted)
+	}
+}
+
+// fakeAuditSink keeps audit records in memory.
+type fakeAuditSink struct {
+	records []AuditRecord
+}
+
+func (s *fakeAuditSink) Record(_ context.Context, record AuditRecord) error {
+	s.records = append(s.records, record)
+	return nil
+}
+
+func TestReconcileAuditsDeletions(t *testing.T) {
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(nil),
+		newOwnedJob("job-oldest", succeededStatus(3*time.Hour)),
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+	sink := &fakeAuditSink{}
+	r.AuditSink = sink
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sink.records) != 2 {
+		t.Fatalf("expected one audit record per deleted job, got %d", len(sink.records))
+	}
+	for _, record := range sink.records {
+		if record.Actor != testNamespace+"/"+testCleanerName {
+			t.Fatalf("unexpected actor %q", record.Actor)
+		}
+		if record.Reason != "succeeded" || record.DryRun || record.Time.IsZero() {
+			t.Fatalf("unexpected audit record %+v", record)
+		}
+	}
+	objects := []string{sink.records[0].Object, sink.records[1].Object}
+	slices.Sort(objects)
+	want := []string{testNamespace + "/job-old", testNamespace + "/job-oldest"}
+	if !slices.Equal(objects, want) {
+		t.Fatalf("expected audited objects %v, got %v", want, objects)
+	}
+}
+
+func TestReconcileKeepsJobsFailedWithListedExitCodes(t *testing.T) {
+	failed := func(name string, startedAgo time.Duration) *batchv1.Job {
+		return newOwnedJob(name, batchv1.JobStatus{
+			Failed:    1,
+			StartTime: &metav1.Time{Time: time.Now().Add(-startedAgo)},
+		})
+	}
+	exited := func(jobName string, exitCode int32) *corev1.Pod {
+		return newJobPod(jobName, corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "main",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode},
+				},
+			}},
+		})
+	}
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.Retain.FailedJobs = 0
+			spec.Retain.KeepFailedExitCodes = []int32{137}
+		}),
+		failed("job-oom", 3*time.Hour),
+		exited("job-oom", 137),
+		failed("job-error", 2*time.Hour),
+		exited("job-error", 1),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	remaining := remainingJobs(t, r)
+	if !remaining["job-oom"] {
+		t.Fatalf("expected job that failed with a kept exit code to survive")
+	}
+	if remaining["job-error"] {
+		t.Fatalf("expected job that failed with another exit code to be deleted")
+	}
+}
+
+func TestReconcilePrioritizesHighResourceFailedJobs(t *testing.T) {
+	failed := func(name string, startedAgo time.Duration, cpu string) *batchv1.Job {
+		job := newOwnedJob(name, batchv1.JobStatus{
+			Failed:    1,
+			StartTime: &metav1.Time{Time: time.Now().Add(-startedAgo)},
+		})
+		job.Spec.Template.Spec.Containers = []corev1.Container{{
+			Name: "main",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+			},
+		}}
+		return job
+	}
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.Retain.PrioritizeHighResource = true
+			spec.MaxDeletionsPerRun = 1
+		}),
+		failed("job-newest", time.Hour, "100m"),
+		failed("job-light", 2*time.Hour, "100m"),
+		failed("job-heavy", 3*time.Hour, "4"),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	remaining := remainingJobs(t, r)
+	if remaining["job-heavy"] {
+		t.Fatalf("expected the high-resource job to be deleted first, got %v", remaining)
+	}
+	if !remaining["job-light"] || !remaining["job-newest"] {
+		t.Fatalf("expected the cap to leave the other jobs, got %v", remaining)
+	}
+}
+
+func TestReconcileDryRunRetentionOnly(t *testing.T) {
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.DryRunRetention = true
+		}),
+		newOwnedJob("job-stuck", activeStatus(2*time.Hour)),
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+	sink := &fakeAuditSink{}
+	r.AuditSink = sink
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	remaining := remainingJobs(t, r)
+	if remaining["job-stuck"] {
+		t.Fatalf("expected stuck job to be really deleted")
+	}
+	if !remaining["job-old"] || !remaining["job-new"] {
+		t.Fatalf("expected retention to only be simulated, got %v", remaining)
+	}
+	if deleted := fetchCleaner(t, r).Status.JobsDeleted; deleted != 1 {
+		t.Fatalf("expected only the stuck job to be counted, got %d", deleted)
+	}
+
+	dryRun := map[string]bool{}
+	for _, record := range sink.records {
+		dryRun[record.Object] = record.DryRun
+	}
+	want := map[string]bool{testNamespace + "/job-stuck": false, testNamespace + "/job-old": true}
+	if !maps.Equal(dryRun, want) {
+		t.Fatalf("expected audit records %v, got %v", want, dryRun)
+	}
+}
+
+func TestReconcileDryRunReportsJobsWouldDelete(t *testing.T) {
+	var deletes []string
+	r := newTestReconciler(t, interceptor.Funcs{
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			deletes = append(deletes, obj.GetName())
+			return c.Delete(ctx, obj, opts...)
+		},
+	},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.DryRun = true
+		}),
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: testCronJobName, Namespace: testNamespace}},
+		newOwnedJob("job-stuck", activeStatus(2*time.Hour)),
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(deletes) != 0 {
+		t.Fatalf("expected no deletes in dry run, got %v", deletes)
+	}
+	cleaner := fetchCleaner(t, r)
+	if cleaner.Status.JobsWouldDelete != 2 {
+		t.Fatalf("expected the stuck and the excess job as candidates, got %d", cleaner.Status.JobsWouldDelete)
+	}
+	if cleaner.Status.JobsDeleted != 0 {
+		t.Fatalf("expected no job to be counted as deleted, got %d", cleaner.Status.JobsDeleted)
+	}
+
+	events := r.Recorder.(*record.FakeRecorder).Events
+	select {
+	case event := <-events:
+		if !strings.Contains(event, "JobsDeletedDryRun") || !strings.Contains(event, "Would delete 2 Jobs") {
+			t.Fatalf("unexpected event %q", event)
+		}
+	default:
+		t.Fatalf("expected a JobsDeletedDryRun event")
+	}
+
+	// Once dry run is off, the candidates go and the count is cleared
+	cleaner.Spec.DryRun = false
+	if err := r.Update(context.Background(), cleaner); err != nil {
+		t.Fatalf("failed to update cleaner: %v", err)
+	}
+	advanceClock(r, 10*time.Minute)
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(deletes, []string{"job-stuck", "job-old"}) {
+		t.Fatalf("expected the candidates to be deleted, got %v", deletes)
+	}
+	if wouldDelete := fetchCleaner(t, r).Status.JobsWouldDelete; wouldDelete != 0 {
+		t.Fatalf("expected no candidates left over from the dry run, got %d", wouldDelete)
+	}
+}
+
+func TestReconcileSkipsJobsOwnedAcrossNamespaces(t *testing.T) {
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: testCronJobName, Namespace: testNamespace, UID: "cronjob-uid"},
+	}
+	// Restored from a backup of another namespace, still naming its original owner
+	restored := newOwnedJob("job-restored", succeededStatus(5*time.Hour))
+	restored.OwnerReferences[0].UID = "other-namespace-cronjob-uid"
+
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(nil),
+		cronJob,
+		restored,
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	remaining := remainingJobs(t, r)
+	if !remaining["job-restored"] {
+		t.Fatalf("expected job owned across namespaces to be skipped")
+	}
+	if remaining["job-old"] {
+		t.Fatalf("expected excess job of the target CronJob to be deleted")
+	}
+
+	events := r.Recorder.(*record.FakeRecorder).Events
+	select {
+	case event := <-events:
+		if !strings.Contains(event, "ForeignOwnerReference") || !strings.Contains(event, "job-restored") {
+			t.Fatalf("unexpected event %q", event)
+		}
+	default:
+		t.Fatalf("expected a ForeignOwnerReference event")
+	}
+}
+
+func TestReconcileBacksOffWhenThrottled(t *testing.T) {
+	deletes := 0
+	r := newTestReconciler(t, interceptor.Funcs{
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			deletes++
+			return apierrors.NewTooManyRequests("slow down", 2)
+		},
+	},
+		newTestCleaner(nil),
+		newOwnedJob("job-oldest", succeededStatus(4*time.Hour)),
+		newOwnedJob("job-older", succeededStatus(3*time.Hour)),
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+	var pauses []time.Duration
+	r.sleep = func(_ context.Context, d time.Duration) error {
+		pauses = append(pauses, d)
+		return nil
+	}
+
+	result, err := reconcileCleaner(t, r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Retry-After wins until the exponential backoff grows past it
+	want := []time.Duration{2 * time.Second, 2 * time.Second, 4 * time.Second}
+	if !slices.Equal(pauses, want) {
+		t.Fatalf("expected pauses %v, got %v", want, pauses)
+	}
+	if deletes != maxThrottleRetries+1 {
+		t.Fatalf("expected the pass to stop after %d attempts on the first job, got %d deletes", maxThrottleRetries+1, deletes)
+	}
+	if result.RequeueAfter != throttledRequeueAfter {
+		t.Fatalf("expected early requeue after %v, got %v", throttledRequeueAfter, result.RequeueAfter)
+	}
+}
+
+func TestReconcileRetriesThrottledRunShortly(t *testing.T) {
+	throttle := true
+	r := newTestReconciler(t, interceptor.Funcs{
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			if throttle {
+				return apierrors.NewTooManyRequests("slow down", 1)
+			}
+			return c.Delete(ctx, obj, opts...)
+		},
+	},
+		newTestCleaner(nil),
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: testCronJobName, Namespace: testNamespace}},
+		newOwnedJob("job-older", succeededStatus(3*time.Hour)),
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+	r.sleep = func(context.Context, time.Duration) error { return nil }
+
+	result, err := reconcileCleaner(t, r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(remainingJobs(t, r)) != 3 {
+		t.Fatalf("expected the throttled pass to delete nothing")
+	}
+
+	// The retry runs well inside the 5m run interval and finishes the job
+	throttle = false
+	advanceClock(r, result.RequeueAfter)
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if remaining := remainingJobs(t, r); len(remaining) != 1 || !remaining["job-new"] {
+		t.Fatalf("expected the retry to delete the remaining excess jobs, got %v", remaining)
+	}
+	if retry := fetchCleaner(t, r).Status.RetryTime; retry != nil {
+		t.Fatalf("expected the retry time to be cleared, got %v", retry)
+	}
+}
+
+func TestReconcileWritesSummaryAnnotation(t *testing.T) {
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.WriteSummaryAnnotation = true
+			spec.MaxDeletionsPerRun = 1
+		}),
+		newOwnedJob("job-oldest", succeededStatus(3*time.Hour)),
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cleaner := fetchCleaner(t, r)
+	value, ok := cleaner.Annotations[lifecyclev1alpha1.LastRunAnnotation]
+	if !ok {
+		t.Fatalf("expected %s annotation to be written", lifecyclev1alpha1.LastRunAnnotation)
+	}
+	var summary runSummary
+	if err := json.Unmarshal([]byte(value), &summary); err != nil {
+		t.Fatalf("failed to decode summary %q: %v", value, err)
+	}
+	if summary.Deleted != 1 || summary.Skipped != 1 || !summary.Time.Equal(cleaner.Status.LastEvaluatedTime) {
+		t.Fatalf("unexpected summary %q", value)
+	}
+	if cleaner.Status.JobsDeleted != 1 {
+		t.Fatalf("expected status to be kept alongside the annotation, got jobsDeleted=%d", cleaner.Status.JobsDeleted)
+	}
+}
+
+func TestReconcileFlagsJobsWithStalePodConditions(t *testing.T) {
+	conditionsAt := func(ago time.Duration) corev1.PodStatus {
+		at := metav1.NewTime(time.Now().Add(-ago))
+		return corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodScheduled, Status: corev1.ConditionTrue, LastTransitionTime: at},
+				{Type: corev1.PodReady, Status: corev1.ConditionTrue, LastTransitionTime: at, LastProbeTime: at},
+			},
+		}
+	}
+
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.CleanupStuck.UsePodConditionAge = true
+		}),
+		// Started recently, but its pod has not moved for hours
+		newOwnedJob("job-stale", activeStatus(30*time.Minute)),
+		newJobPod("job-stale", conditionsAt(3*time.Hour)),
+		// Started long ago, but its pod is still making progress
+		newOwnedJob("job-progressing", activeStatus(5*time.Hour)),
+		newJobPod("job-progressing", conditionsAt(10*time.Minute)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	names := fetchCleaner(t, r).Status.StuckJobNames
+	if !slices.Equal(names, []string{"job-stale"}) {
+		t.Fatalf("expected only job-stale to be flagged as stuck, got %v", names)
+	}
+}
+
+func TestReconcileResetsCountersWhenRecreated(t *testing.T) {
+	original := newTestCleaner(nil)
+	original.UID = "original-uid"
+
+	r := newTestReconciler(t, interceptor.Funcs{},
+		original,
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stale := fetchCleaner(t, r).Status
+	if stale.JobsDeleted != 1 || stale.ObservedUID != "original-uid" {
+		t.Fatalf("unexpected status before recreation: %+v", stale)
+	}
+
+	// Recreate the cleaner under the same name, restoring the old status
+	ctx := context.Background()
+	if err := r.Delete(ctx, fetchCleaner(t, r)); err != nil {
+		t.Fatalf("failed to delete cleaner: %v", err)
+	}
+	recreated := newTestCleaner(nil)
+	recreated.UID = "recreated-uid"
+	if err := r.Create(ctx, recreated); err != nil {
+		t.Fatalf("failed to recreate cleaner: %v", err)
+	}
+	recreated.Status = stale
+	if err := r.Status().Update(ctx, recreated); err != nil {
+		t.Fatalf("failed to restore status: %v", err)
+	}
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	status := fetchCleaner(t, r).Status
+	if status.JobsDeleted != 0 || status.PodsDeleted != 0 {
+		t.Fatalf("expected counters to be reset, got jobsDeleted=%d podsDeleted=%d", status.JobsDeleted, status.PodsDeleted)
+	}
+	if status.ObservedUID != "recreated-uid" {
+		t.Fatalf("expected observed UID to be updated, got %q", status.ObservedUID)
+	}
+}
+
+func TestReconcileStopsAtLifetimeDeletionCeiling(t *testing.T) {
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.LifetimeDeletionCeiling = 2
+		}),
+		newOwnedJob("job-1", succeededStatus(5*time.Hour)),
+		newOwnedJob("job-2", succeededStatus(4*time.Hour)),
+		newOwnedJob("job-3", succeededStatus(3*time.Hour)),
+		newOwnedJob("job-4", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-5", succeededStatus(time.Hour)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if remaining := remainingJobs(t, r); len(remaining) != 3 {
+		t.Fatalf("expected deletions to stop at the ceiling, got %d remaining jobs", len(remaining))
+	}
+	cleaner := fetchCleaner(t, r)
+	condition := meta.FindStatusCondition(cleaner.Status.Conditions, "CeilingReached")
+	if condition == nil || condition.Status != metav1.ConditionTrue {
+		t.Fatalf("expected CeilingReached condition, got %+v", condition)
+	}
+
+	// Later runs keep refusing to delete until the spec changes
+	advanceClock(r, cleaner.Spec.RunInterval.Duration)
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if remaining := remainingJobs(t, r); len(remaining) != 3 {
+		t.Fatalf("expected no further deletions past the ceiling, got %d remaining jobs", len(remaining))
+	}
+}
+
+// liveReader stands in for the manager's API reader and records the lists
+// made through it.
+type liveReader struct {
+	client.Reader
+	lists int
+}
+
+func (r *liveReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	r.lists++
+	return r.Reader.List(ctx, list, opts...)
+}
+
+func TestReconcileListsLiveWithStrongConsistency(t *testing.T) {
+	jobLists := 0
+	r := newTestReconciler(t, interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if _, ok := list.(*batchv1.JobList); ok {
+				jobLists++
+			}
+			return c.List(ctx, list, opts...)
+		},
+	},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.StrongConsistency = true
+		}),
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+	live := &liveReader{Reader: r.Client}
+	r.APIReader = live
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The live reader is backed by the same fake client, so the one job list
+	// that reached the client must have come through it
+	if live.lists != 1 || jobLists != 1 {
+		t.Fatalf("expected jobs to be listed live only, got %d live of %d job lists", live.lists, jobLists)
+	}
+	if remaining := remainingJobs(t, r); remaining["job-old"] {
+		t.Fatalf("expected the live list to drive deletions")
+	}
+}
+
+func TestReconcileTargetsJobsBySelector(t *testing.T) {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "batch-report"}}
+	tests := []struct {
+		name   string
+		mutate func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec)
+		want   []string
+	}{
+		{
+			name: "selector only",
+			mutate: func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+				spec.CronJobName = ""
+				spec.Selector = selector
+			},
+			want: []string{"job-owned-only"},
+		},
+		{
+			name:   "owner only",
+			mutate: nil,
+			want:   []string{"job-labeled-only"},
+		},
+		{
+			name: "selector and owner",
+			mutate: func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+				spec.Selector = selector
+			},
+			want: []string{"job-labeled-only", "job-owned-only"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ownedLabeled := newOwnedJob("job-owned-labeled", succeededStatus(time.Hour))
+			ownedLabeled.Labels = map[string]string{"app": "batch-report"}
+			labeled := newOwnedJob("job-labeled-only", succeededStatus(time.Hour))
+			labeled.OwnerReferences = nil
+			labeled.Labels = map[string]string{"app": "batch-report"}
+			r := newTestReconciler(t, interceptor.Funcs{},
+				newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+					spec.Retain.SuccessfulJobs = 0
+					if tt.mutate != nil {
+						tt.mutate(spec)
+					}
+				}),
+				&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: testCronJobName, Namespace: testNamespace}},
+				ownedLabeled,
+				labeled,
+				newOwnedJob("job-owned-only", succeededStatus(time.Hour)),
+			)
+
+			if _, err := reconcileCleaner(t, r); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			remaining := remainingJobs(t, r)
+			if len(remaining) != len(tt.want) {
+				t.Fatalf("expected remaining jobs %v, got %v", tt.want, remaining)
+			}
+			for _, name := range tt.want {
+				if !remaining[name] {
+					t.Fatalf("expected remaining jobs %v, got %v", tt.want, remaining)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileCallsPostCleanupWebhook(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		summaries []CleanupSummary
+		r         *CronExecutionCleanerReconciler
+	)
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var summary CleanupSummary
+		if err := json.NewDecoder(req.Body).Decode(&summary); err != nil {
+			t.Errorf("failed to decode webhook body: %v", err)
+		}
+		// The run is recorded before anyone hears about it
+		if evaluated := fetchCleaner(t, r).Status.LastEvaluatedTime; evaluated == nil || !evaluated.Time.Equal(summary.Time) {
+			t.Errorf("expected the status to be written before the webhook is called")
+		}
+		mu.Lock()
+		summaries = append(summaries, summary)
+		mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	r = newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.PostCleanupWebhook = server.URL
+		}),
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: testCronJobName, Namespace: testNamespace}},
+		newOwnedJob("job-oldest", succeededStatus(3*time.Hour)),
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+	r.PostCleanupWebhookHosts = []string{strings.TrimPrefix(server.URL, "http://")}
+	reconcileAndWait := func() error {
+		_, err := reconcileCleaner(t, r)
+		r.postCleanupCalls.Wait()
+		return err
+	}
+
+	if err := reconcileAndWait(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(summaries) != 1 {
+		t.Fatalf("expected the webhook to be called once, got %d calls", len(summaries))
+	}
+	summary := summaries[0]
+	wantJobs := []string{testNamespace + "/job-oldest", testNamespace + "/job-old"}
+	slices.Sort(wantJobs)
+	slices.Sort(summary.Jobs)
+	if summary.Cleaner != testNamespace+"/"+testCleanerName || summary.Deleted != 2 ||
+		summary.DeletedByReason["succeeded"] != 2 || !slices.Equal(summary.Jobs, wantJobs) {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+
+	// Nothing deleted, nothing to report
+	advanceClock(r, 10*time.Minute)
+	if err := reconcileAndWait(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(summaries) != 1 {
+		t.Fatalf("expected no webhook call without deletions, got %d calls", len(summaries))
+	}
+
+	// A failing webhook does not fail the reconcile
+	status = http.StatusInternalServerError
+	if err := r.Create(context.Background(), newOwnedJob("job-newer", succeededStatus(0))); err != nil {
+		t.Fatalf("failed to create job: %v", err)
+	}
+	advanceClock(r, 10*time.Minute)
+	if err := reconcileAndWait(); err != nil {
+		t.Fatalf("expected a failing webhook not to fail the reconcile, got %v", err)
+	}
+	if len(summaries) != 2 {
+		t.Fatalf("expected the webhook to be called again, got %d calls", len(summaries))
+	}
+}
+
+func TestReconcileSkipsPostCleanupWebhookToHostNotAllowed(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		calls.Add(1)
+	}))
+	defer server.Close()
+
+	r := newTestReconciler(t, interceptor.Funcs{},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.PostCleanupWebhook = server.URL
+		}),
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: testCronJobName, Namespace: testNamespace}},
+		newOwnedJob("job-oldest", succeededStatus(3*time.Hour)),
+		newOwnedJob("job-old", succeededStatus(2*time.Hour)),
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+	r.PostCleanupWebhookHosts = []string{"hooks.example.com"}
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r.postCleanupCalls.Wait()
+	if calls.Load() != 0 {
+		t.Fatalf("expected a webhook to a host not allowed not to be called, got %d calls", calls.Load())
+	}
+	if len(remainingJobs(t, r)) != 1 {
+		t.Fatalf("expected the run to delete Jobs regardless of the webhook")
+	}
+	events := r.Recorder.(*record.FakeRecorder).Events
+	for {
+		select {
+		case e := <-events:
+			if strings.Contains(e, "PostCleanupWebhookNotAllowed") {
+				return
+			}
+		default:
+			t.Fatalf("expected a PostCleanupWebhookNotAllowed event")
+		}
+	}
+}
+
+func TestPostCleanupHostAllowed(t *testing.T) {
+	r := &CronExecutionCleanerReconciler{PostCleanupWebhookHosts: []string{"hooks.example.com", "alerts.example.com:8443"}}
+	for webhook, want := range map[string]bool{
+		"https://hooks.example.com/cleanup":       true,
+		"https://hooks.example.com:9000/cleanup":  true,
+		"https://alerts.example.com:8443/cleanup": true,
+		"https://alerts.example.com/cleanup":      false,
+		"http://169.254.169.254/latest/meta-data": false,
+		"https://hooks.example.com.evil.io/":      false,
+	} {
+		if got := r.postCleanupHostAllowed(webhook); got != want {
+			t.Errorf("postCleanupHostAllowed(%q) = %v, want %v", webhook, got, want)
+		}
+	}
+	if (&CronExecutionCleanerReconciler{}).postCleanupHostAllowed("https://hooks.example.com/") {
+		t.Errorf("expected no host to be allowed by default")
+	}
+}
+
+func TestReconcileSpreadsDeletionsAtConfiguredRate(t *testing.T) {
+	objs := []client.Object{
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.DeletionRateLimit = &lifecyclev1alpha1.DeletionRateLimit{PerMinute: 2, Burst: 2}
+			spec.RunInterval = metav1.Duration{Duration: 30 * time.Second}
+		}),
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: testCronJobName, Namespace: testNamespace}},
+	}
+	for i := 0; i < 10; i++ {
+		objs = append(objs, newOwnedJob(fmt.Sprintf("job-%d", i), succeededStatus(time.Duration(i+1)*time.Hour)))
+	}
+	r := newTestReconciler(t, interceptor.Funcs{}, objs...)
+
+	// Each step advances the clock, reconciles and checks the total number
+	// of Jobs deleted so far
+	steps := []struct {
+		advance time.Duration
+		deleted int
+	}{
+		// A new bucket starts full
+		{0, 2},
+		// One token every 30 seconds, runs every 30 seconds
+		{30 * time.Second, 3},
+		{10 * time.Second, 3},
+		{20 * time.Second, 4},
+		{30 * time.Second, 5},
+		// A quiet period refills no more than the burst
+		{10 * time.Minute, 7},
+		{time.Minute, 9},
+	}
+	for i, step := range steps {
+		advanceClock(r, step.advance)
+		if _, err := reconcileCleaner(t, r); err != nil {
+			t.Fatalf("step %d: unexpected error: %v", i, err)
+		}
+		if deleted := 10 - len(remainingJobs(t, r)); deleted != step.deleted {
+			t.Fatalf("step %d: expected %d jobs deleted in total, got %d", i, step.deleted, deleted)
+		}
+	}
+	if tokens := fetchCleaner(t, r).Status.DeletionTokens; tokens != 0 {
+		t.Fatalf("expected the bucket to be empty, got %d tokens", tokens)
+	}
+}
+
+func TestReconcileIdleRateLimitedCleanerSkipsStatusWrites(t *testing.T) {
+	statusWrites := 0
+	r := newTestReconciler(t, interceptor.Funcs{
+		SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+			statusWrites++
+			return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+		},
+	},
+		newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.DeletionRateLimit = &lifecyclev1alpha1.DeletionRateLimit{PerMinute: 2, Burst: 2}
+		}),
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: testCronJobName, Namespace: testNamespace}},
+		newOwnedJob("job-new", succeededStatus(time.Hour)),
+	)
+
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if statusWrites != 1 {
+		t.Fatalf("expected the first run to write status, got %d writes", statusWrites)
+	}
+
+	// Nothing to delete and a full bucket: the status stays as it was
+	advanceClock(r, 5*time.Minute)
+	if _, err := reconcileCleaner(t, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if statusWrites != 1 {
+		t.Fatalf("expected no status write for an idle run, got %d writes", statusWrites)
+	}
+}
+
+func TestReconcileFailedDeletionsSpendNoTokens(t *testing.T) {
+	gr := schema.GroupResource{Group: "batch", Resource: "jobs"}
+	for name, deleteErr := range map[string]error{
+		"error":    apierrors.NewInternalError(errors.New("etcd unavailable")),
+		"conflict": apierrors.NewConflict(gr, "job-old", errors.New("precondition failed")),
+		"notFound": apierrors.NewNotFound(gr, "job-old"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := newTestReconciler(t, interceptor.Funcs{
+				Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+					if _, ok := obj.(*batchv1.Job); ok {
+						return deleteErr
+					}
+					return c.Delete(ctx, obj, opts...)
+				},
+			},
+				newTestCleaner(func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+					spec.DeletionRateLimit = &lifecyclev1alpha1.DeletionRateLimit{PerMinute: 2, Burst: 2}
+				}),
+				&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: testCronJobName, Namespace: testNamespace}},
+				newOwnedJob("job-old", succeededStatus(3*time.Hour)),
+				newOwnedJob("job-older", succeededStatus(4*time.Hour)),
+				newOwnedJob("job-oldest", succeededStatus(5*time.Hour)),
+			)
+
+			if _, err := reconcileCleaner(t, r); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tokens := fetchCleaner(t, r).Status.DeletionTokens; tokens != 2 {
+				t.Fatalf("expected failed deletions to leave 2 tokens, got %d", tokens)
+			}
+		})
+	}
+}
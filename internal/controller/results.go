@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// ReconcileResult summarizes a single reconcile of a cleaner, for binaries
+// that embed the controller and want to act on cleanup results.
+type ReconcileResult struct {
+	// Cleaner that was reconciled
+	Cleaner types.NamespacedName
+
+	// Number of Jobs deleted, in total and by reason
+	Deleted         int
+	DeletedByReason map[string]int
+
+	// Number of stuck Jobs quarantined instead of deleted
+	Quarantined int
+
+	// Number of Jobs selected for deletion that were left for a later run
+	Skipped int
+
+	// Error the reconcile ended with, if any
+	Err error
+}
+
+// sendResult hands the result to the Results channel without waiting. When
+// the consumer falls behind, the result is dropped so that reconciles are
+// never held up.
+func (r *CronExecutionCleanerReconciler) sendResult(ctx context.Context, result ReconcileResult) {
+	if r.Results == nil {
+		return
+	}
+	select {
+	case r.Results <- result:
+	default:
+		ctrl.LoggerFrom(ctx).V(1).Info("Results channel is full, dropping reconcile result", "cleaner", result.Cleaner)
+	}
+}
@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+
+	lifecyclev1alpha1 "github.com/bhatpriyanka8/cron-execution-cleaner/api/v1alpha1"
+	"github.com/bhatpriyanka8/cron-execution-cleaner/internal/scaffold"
+)
+
+func TestScaffoldSamplesAreValid(t *testing.T) {
+	for _, name := range scaffold.Scenarios() {
+		t.Run(name, func(t *testing.T) {
+			manifest, err := scaffold.Render(name)
+			if err != nil {
+				t.Fatalf("failed to render sample: %v", err)
+			}
+
+			var cleaner lifecyclev1alpha1.CronExecutionCleaner
+			if err := yaml.UnmarshalStrict(manifest, &cleaner); err != nil {
+				t.Fatalf("sample does not unmarshal into a CronExecutionCleaner: %v", err)
+			}
+			if cleaner.Kind != "CronExecutionCleaner" {
+				t.Fatalf("expected kind CronExecutionCleaner, got %q", cleaner.Kind)
+			}
+
+			cleaner.Spec = EffectiveSpec(&cleaner)
+			if err := validateSpec(context.Background(), &cleaner); err != nil {
+				t.Fatalf("sample spec is invalid: %v", err)
+			}
+		})
+	}
+}
+
+func TestScaffoldUnknownScenario(t *testing.T) {
+	if _, err := scaffold.Render("does-not-exist"); err == nil {
+		t.Fatalf("expected error for unknown scenario")
+	}
+}
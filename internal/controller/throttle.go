@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	// throttleBaseDelay is the first pause after the API server throttles a
+	// deletion. It doubles with every further throttled attempt.
+	throttleBaseDelay = time.Second
+
+	// maxThrottleRetries is the number of consecutive throttled attempts
+	// after which a cleanup pass stops early.
+	maxThrottleRetries = 3
+
+	// throttledRequeueAfter is when a pass that stopped early is retried.
+	throttledRequeueAfter = 30 * time.Second
+)
+
+// throttleBackoff tracks consecutive 429 responses from the API server.
+type throttleBackoff struct {
+	attempts int
+}
+
+// next returns how long to pause before retrying a throttled request,
+// honoring the server's Retry-After if it asks for longer. It returns false
+// once throttling has persisted for maxThrottleRetries attempts.
+func (b *throttleBackoff) next(err error) (time.Duration, bool) {
+	if b.attempts >= maxThrottleRetries {
+		return 0, false
+	}
+	delay := throttleBaseDelay << b.attempts
+	if seconds, ok := apierrors.SuggestsClientDelay(err); ok {
+		if retryAfter := time.Duration(seconds) * time.Second; retryAfter > delay {
+			delay = retryAfter
+		}
+	}
+	b.attempts++
+	return delay, true
+}
+
+// reset forgets earlier throttled attempts after a request went through.
+func (b *throttleBackoff) reset() {
+	b.attempts = 0
+}
+
+// pause waits for the given duration or until the context is done.
+func (r *CronExecutionCleanerReconciler) pause(ctx context.Context, d time.Duration) error {
+	if r.sleep != nil {
+		return r.sleep(ctx, d)
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
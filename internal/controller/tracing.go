@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// tracerName is the instrumentation scope of the spans started by the
+// controller.
+const tracerName = "github.com/bhatpriyanka8/cron-execution-cleaner/internal/controller"
+
+// Names of the spans started around the phases of a reconcile.
+const (
+	spanList     = "cleaner.list"
+	spanClassify = "cleaner.classify"
+	spanPlan     = "cleaner.plan"
+	spanDelete   = "cleaner.delete"
+)
+
+// startSpan starts a span for a phase of the reconcile, as a child of any
+// span already in the context. Without a tracer provider the span comes from
+// the OpenTelemetry no-op provider, so tracing costs nothing when disabled.
+func (r *CronExecutionCleanerReconciler) startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	var provider trace.TracerProvider = noop.NewTracerProvider()
+	if r.TracerProvider != nil {
+		provider = r.TracerProvider
+	}
+	return provider.Tracer(tracerName).Start(ctx, name)
+}
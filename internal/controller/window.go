@@ -0,0 +1,30 @@
+package controller
+
+import (
+	"time"
+
+	lifecyclev1alpha1 "github.com/bhatpriyanka8/cron-execution-cleaner/api/v1alpha1"
+)
+
+// maintenanceWindowLayout is the layout of a maintenance window's start.
+const maintenanceWindowLayout = "15:04"
+
+// maintenanceWindowAt reports whether the daily window is open at now, and
+// its next boundary: when it closes if it is open, when it opens otherwise.
+func maintenanceWindowAt(window *lifecyclev1alpha1.MaintenanceWindow, now time.Time) (bool, time.Time) {
+	start, err := time.Parse(maintenanceWindowLayout, window.Start)
+	if err != nil {
+		// Rejected by validateSpec; never open rather than always
+		return false, now.Add(24 * time.Hour)
+	}
+	now = now.UTC()
+	// The window opened last at or before now
+	opened := time.Date(now.Year(), now.Month(), now.Day(), start.Hour(), start.Minute(), 0, 0, time.UTC)
+	if opened.After(now) {
+		opened = opened.AddDate(0, 0, -1)
+	}
+	if closes := opened.Add(window.Duration.Duration); now.Before(closes) {
+		return true, closes
+	}
+	return false, opened.AddDate(0, 0, 1)
+}
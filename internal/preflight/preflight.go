@@ -0,0 +1,69 @@
+// Package preflight checks, before the controller is deployed, that its
+// credentials can reach the API server and carry the permissions cleanup
+// needs.
+package preflight
+
+import (
+	"context"
+	"fmt"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Permission is a verb on a namespaced resource.
+type Permission struct {
+	Group    string
+	Resource string
+	Verb     string
+}
+
+// String returns the permission as kubectl auth can-i spells it, e.g.
+// "delete jobs.batch".
+func (p Permission) String() string {
+	if p.Group == "" {
+		return p.Verb + " " + p.Resource
+	}
+	return p.Verb + " " + p.Resource + "." + p.Group
+}
+
+// RequiredPermissions are the permissions a cleaner needs in the namespace
+// of its target CronJob.
+var RequiredPermissions = []Permission{
+	{Group: "batch", Resource: "cronjobs", Verb: "get"},
+	{Group: "batch", Resource: "cronjobs", Verb: "list"},
+	{Group: "batch", Resource: "cronjobs", Verb: "watch"},
+	{Group: "batch", Resource: "jobs", Verb: "get"},
+	{Group: "batch", Resource: "jobs", Verb: "list"},
+	{Group: "batch", Resource: "jobs", Verb: "watch"},
+	{Group: "batch", Resource: "jobs", Verb: "delete"},
+	{Group: "", Resource: "pods", Verb: "list"},
+}
+
+// Check asks the API server whether the client's own identity holds every
+// required permission in the namespace and returns those it lacks. Access
+// reviews only evaluate the request and never change anything, so Check is
+// safe to run against any cluster. An error means the API server could not
+// answer.
+func Check(ctx context.Context, c client.Client, namespace string) ([]Permission, error) {
+	var missing []Permission
+	for _, permission := range RequiredPermissions {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: namespace,
+					Group:     permission.Group,
+					Resource:  permission.Resource,
+					Verb:      permission.Verb,
+				},
+			},
+		}
+		if err := c.Create(ctx, review); err != nil {
+			return nil, fmt.Errorf("failed to review %q: %w", permission, err)
+		}
+		if !review.Status.Allowed {
+			missing = append(missing, permission)
+		}
+	}
+	return missing, nil
+}
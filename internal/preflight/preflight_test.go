@@ -0,0 +1,90 @@
+package preflight
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// newReviewClient returns a client answering access reviews with allowed,
+// and recording the namespace of every review.
+func newReviewClient(allowed func(attrs *authorizationv1.ResourceAttributes) bool, namespaces *[]string) client.Client {
+	return fake.NewClientBuilder().
+		WithScheme(clientgoscheme.Scheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				review, ok := obj.(*authorizationv1.SelfSubjectAccessReview)
+				if !ok {
+					return c.Create(ctx, obj, opts...)
+				}
+				attrs := review.Spec.ResourceAttributes
+				*namespaces = append(*namespaces, attrs.Namespace)
+				review.Status.Allowed = allowed(attrs)
+				return nil
+			},
+		}).
+		Build()
+}
+
+func TestCheckReportsMissingDeletePermission(t *testing.T) {
+	var namespaces []string
+	c := newReviewClient(func(attrs *authorizationv1.ResourceAttributes) bool {
+		return attrs.Resource != "jobs" || attrs.Verb != "delete"
+	}, &namespaces)
+
+	missing, err := Check(context.Background(), c, "batch")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []Permission{{Group: "batch", Resource: "jobs", Verb: "delete"}}
+	if !slices.Equal(missing, want) {
+		t.Fatalf("expected missing permissions %v, got %v", want, missing)
+	}
+	if missing[0].String() != "delete jobs.batch" {
+		t.Fatalf("unexpected permission string %q", missing[0].String())
+	}
+	if len(namespaces) != len(RequiredPermissions) {
+		t.Fatalf("expected one review per required permission, got %d", len(namespaces))
+	}
+	for _, namespace := range namespaces {
+		if namespace != "batch" {
+			t.Fatalf("expected every review to target the namespace, got %q", namespace)
+		}
+	}
+}
+
+func TestCheckReportsNothingWhenAllowed(t *testing.T) {
+	var namespaces []string
+	c := newReviewClient(func(*authorizationv1.ResourceAttributes) bool { return true }, &namespaces)
+
+	missing, err := Check(context.Background(), c, "batch")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(missing) != 0 {
+		t.Fatalf("expected no missing permissions, got %v", missing)
+	}
+}
+
+func TestCheckFailsWhenAPIServerUnreachable(t *testing.T) {
+	c := fake.NewClientBuilder().
+		WithScheme(clientgoscheme.Scheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(context.Context, client.WithWatch, client.Object, ...client.CreateOption) error {
+				return errors.New("connection refused")
+			},
+		}).
+		Build()
+
+	if _, err := Check(context.Background(), c, "batch"); err == nil {
+		t.Fatalf("expected an error when the API server cannot be reached")
+	}
+}
@@ -0,0 +1,120 @@
+// Package scaffold generates sample CronExecutionCleaner manifests for common
+// scenarios. Samples are built from the API types, so they always match the
+// fields the controller understands.
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	lifecyclev1alpha1 "github.com/bhatpriyanka8/cron-execution-cleaner/api/v1alpha1"
+)
+
+// scenario describes one sample manifest.
+type scenario struct {
+	comment string
+	mutate  func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec)
+}
+
+var scenarios = map[string]scenario{
+	"basic": {
+		comment: "Keeps the newest successful and failed Jobs of a CronJob and\n" +
+			"deletes older executions.",
+		mutate: func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {},
+	},
+	"stuck": {
+		comment: "Applies retention and also deletes Jobs that have been running for\n" +
+			"longer than cleanupStuck.stuckAfter.",
+		mutate: func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.CleanupStuck = lifecyclev1alpha1.CleanupStuckPolicy{
+				Enabled:    true,
+				StuckAfter: metav1.Duration{Duration: 2 * time.Hour},
+			}
+		},
+	},
+	"dry-run": {
+		comment: "Like stuck, but only logs and audits the Jobs it would delete.\n" +
+			"Remove dryRun once the selection looks right.",
+		mutate: func(spec *lifecyclev1alpha1.CronExecutionCleanerSpec) {
+			spec.CleanupStuck = lifecyclev1alpha1.CleanupStuckPolicy{
+				Enabled:    true,
+				StuckAfter: metav1.Duration{Duration: 2 * time.Hour},
+			}
+			spec.DryRun = true
+		},
+	},
+}
+
+// Scenarios returns the names of the available scenarios.
+func Scenarios() []string {
+	return []string{"basic", "stuck", "dry-run"}
+}
+
+// Sample returns the CronExecutionCleaner for the given scenario.
+func Sample(name string) (*lifecyclev1alpha1.CronExecutionCleaner, error) {
+	s, ok := scenarios[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown scenario %q, expected one of %s",
+			name, strings.Join(Scenarios(), ", "))
+	}
+
+	cleaner := &lifecyclev1alpha1.CronExecutionCleaner{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: lifecyclev1alpha1.GroupVersion.String(),
+			Kind:       "CronExecutionCleaner",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sample-cron-execution-cleaner",
+			Namespace: "default",
+		},
+		Spec: lifecyclev1alpha1.CronExecutionCleanerSpec{
+			Namespace:   "default",
+			CronJobName: "example-cronjob",
+			Retain: lifecyclev1alpha1.RetentionPolicy{
+				SuccessfulJobs: 3,
+				FailedJobs:     3,
+			},
+			RunInterval: metav1.Duration{Duration: 5 * time.Minute},
+		},
+	}
+	s.mutate(&cleaner.Spec)
+	return cleaner, nil
+}
+
+// Render returns the commented YAML manifest for the given scenario.
+func Render(name string) ([]byte, error) {
+	cleaner, err := Sample(name)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cleaner)
+	if err != nil {
+		return nil, err
+	}
+	// Server-populated fields have no place in a sample
+	obj := &unstructured.Unstructured{Object: content}
+	unstructured.RemoveNestedField(obj.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(obj.Object, "status")
+
+	body, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "# Sample CronExecutionCleaner: %s\n#\n", name)
+	for _, line := range strings.Split(scenarios[name].comment, "\n") {
+		fmt.Fprintf(&out, "# %s\n", line)
+	}
+	out.WriteString("#\n# Replace cronJobName with the CronJob whose executions should be cleaned.\n")
+	out.Write(body)
+	return out.Bytes(), nil
+}